@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/kernel"
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [program files...]",
+	Short: "run the given program files on the simulated os",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runPrograms,
+	// runtime errors are reported by Execute without the usage text
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+var (
+	runConfig         = kernel.DefaultConfig()
+	runPriorities     []int
+	runMemoryStats    bool
+	runInputPath      string
+	runOutputPath     string
+	runReferencesPath string
+	runRecordPath     string
+)
+
+func init() {
+	runCmd.Flags().IntVarP(&runConfig.Quantum, "quantum", "q", runConfig.Quantum, "number of instructions per time slice")
+	runCmd.Flags().StringVarP(&runConfig.Policy, "policy", "p", runConfig.Policy, "scheduling policy: rr, fcfs, sjf, srtf, priority or mlfq")
+	runCmd.Flags().StringVar(&runConfig.DeadlockRecovery, "deadlock-recovery", runConfig.DeadlockRecovery, "deadlock recovery strategy: none, abort-youngest, abort-all or preempt")
+	runCmd.Flags().BoolVar(&runConfig.Avoidance, "avoidance", runConfig.Avoidance, "grant resources with the banker's algorithm to avoid deadlocks")
+	runCmd.Flags().IntVar(&runConfig.Memory.Words, "memory-words", runConfig.Memory.Words, "number of words in the RAM")
+	runCmd.Flags().IntVar(&runConfig.Memory.PCBSize, "pcb-size", runConfig.Memory.PCBSize, "number of words reserved for the pcb of every process")
+	runCmd.Flags().IntVar(&runConfig.Memory.VariablesSize, "variables", runConfig.Memory.VariablesSize, "number of variables every process can declare")
+	runCmd.Flags().IntVar(&runConfig.Memory.PageSize, "page-size", runConfig.Memory.PageSize, "number of words in a page, processes are paged instead of allocated contiguously if it's set")
+	runCmd.Flags().StringVar(&runConfig.Memory.Replacement, "replacement", runConfig.Memory.Replacement, "page replacement policy: fifo, lru, clock or optimal")
+	runCmd.Flags().StringVar(&runReferencesPath, "references", "", "reference string recorded with --record-references that the optimal page replacement evicts pages with")
+	runCmd.Flags().StringVar(&runRecordPath, "record-references", "", "file the pages referenced by the programs are written to")
+	runCmd.Flags().StringVar(&runConfig.Memory.Strategy, "allocation", runConfig.Memory.Strategy, "memory allocation strategy: first-fit, best-fit, worst-fit or next-fit")
+	runCmd.Flags().StringVar(&runConfig.SwapDir, "swap-dir", runConfig.SwapDir, "directory processes are swapped out to when the memory is full, swapping is disabled if it's empty")
+	runCmd.Flags().BoolVar(&runMemoryStats, "memory-stats", false, "print the fragmentation of the memory after every program is loaded")
+	runCmd.Flags().StringVar(&runInputPath, "input", "", "file the programs read input from instead of the standard input")
+	runCmd.Flags().StringVar(&runOutputPath, "output", "", "file the programs print to instead of the standard output")
+	runCmd.Flags().IntSliceVar(&runPriorities, "priorities", nil, "static priority of each program in order, lower runs first")
+	rootCmd.AddCommand(runCmd)
+}
+
+func runPrograms(cmd *cobra.Command, args []string) error {
+	if runConfig.Memory.Replacement == memory.OptimalReplacement && runReferencesPath == "" {
+		return errors.New("--replacement optimal needs the reference string of a previous run, see --references")
+	}
+	if runInputPath != "" {
+		input, err := os.Open(runInputPath)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+		runConfig.Input = input
+	}
+	if runOutputPath != "" {
+		output, err := os.Create(runOutputPath)
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+		runConfig.Output = output
+	}
+
+	if runReferencesPath != "" {
+		data, err := os.ReadFile(runReferencesPath)
+		if err != nil {
+			return err
+		}
+		if runConfig.References, err = memory.ParseReferences(strings.Split(string(data), "\n")); err != nil {
+			return fmt.Errorf("%s: %w", runReferencesPath, err)
+		}
+	}
+
+	k, err := kernel.NewKernel(runConfig)
+	if err != nil {
+		return err
+	}
+
+	// load every program into memory and admit it to the ready queue
+	ids := make([]int, len(args))
+	for idx, path := range args {
+		process, err := k.LoadProgram(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if idx < len(runPriorities) {
+			process.Priority = runPriorities[idx]
+		}
+		ids[idx] = process.Id
+	}
+	if runMemoryStats {
+		for idx, stats := range k.Fragmentation() {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: largest hole %d, free %d, external fragmentation %.2f\n",
+				args[idx], stats.LargestHole, stats.TotalFree, stats.ExternalFragmentation)
+		}
+	}
+
+	// execute one instruction per tick until all processes terminate
+	runErr := k.Run()
+	if runMemoryStats && runConfig.Memory.PageSize > 0 {
+		for idx, id := range ids {
+			stats := k.PageStats(id)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: %d hits, %d faults, %d evictions\n", args[idx], stats.Hits, stats.Faults, stats.Evictions)
+		}
+	}
+	if runRecordPath != "" {
+		references := make([]string, 0, len(k.References()))
+		for _, reference := range k.References() {
+			references = append(references, reference.String())
+		}
+		if err := os.WriteFile(runRecordPath, []byte(strings.Join(references, "\n")), 0666); err != nil {
+			return errors.Join(runErr, err)
+		}
+	}
+	return runErr
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/interpreter"
+	"github.com/KhaledHegazy222/os-simulator/pkg/systemcalls"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [program files...]",
+	Short: "check the given program files for errors without running them",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  validatePrograms,
+	// the diagnostics are the output, Execute only reports how many were found
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
+
+func validatePrograms(cmd *cobra.Command, args []string) error {
+	os := systemcalls.NewOS()
+	problems := 0
+	for _, path := range args {
+		unparsedCode, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for _, diagnostic := range interpreter.Validate(path, unparsedCode) {
+			fmt.Fprintln(cmd.OutOrStdout(), diagnostic)
+			problems++
+		}
+	}
+	if problems > 0 {
+		return fmt.Errorf("found %d problems", problems)
+	}
+	return nil
+}
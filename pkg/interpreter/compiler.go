@@ -0,0 +1,172 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// operandKind describes how an operand of a compiled instruction is decoded.
+type operandKind byte
+
+const (
+	integerOperand  operandKind = 'i'
+	stringOperand   operandKind = 's'
+	variableOperand operandKind = 'v'
+	operatorOperand operandKind = 'o'
+)
+
+// operand is a typed operand of a compiled instruction.
+type operand struct {
+	kind operandKind
+	text string
+	// opcode is the opcode of an operator operand once it's decoded
+	opcode int
+}
+
+// compiledInstruction is an instruction decoded from a code word, its operands keep the kinds
+// they were compiled with so they're never classified again.
+type compiledInstruction struct {
+	opcode   int
+	operands []operand
+}
+
+// opcodes lists the commands and expression operators in the order of their opcodes.
+var opcodes = []string{
+	"assign", "print", "claim", "semInit", "semWait", "semSignal", "writeFile", "readFile", "printFromTo",
+	"jmp", "jz", "jnz", "add", "sub", "mul", "div", "mod", "eq", "lt", "input",
+}
+
+// opcodeOf maps every command and expression operator to its opcode.
+var opcodeOf = func() map[string]int {
+	codes := make(map[string]int, len(opcodes))
+	for opcode, name := range opcodes {
+		codes[name] = opcode
+	}
+	return codes
+}()
+
+// commands holds the command of every opcode, the opcodes of expression operators that aren't
+// commands hold the zero command.
+var commands = func() []allowedCommand {
+	byOpcode := make([]allowedCommand, len(opcodes))
+	for opcode, name := range opcodes {
+		byOpcode[opcode] = availableCommands[name]
+	}
+	return byOpcode
+}()
+
+// operators holds the expression operator of every opcode, the opcodes of commands that aren't
+// operators hold the zero operator.
+var operators = func() []operator {
+	byOpcode := make([]operator, len(opcodes))
+	for opcode, name := range opcodes {
+		byOpcode[opcode] = expressionOperators[name]
+	}
+	return byOpcode
+}()
+
+var (
+	// ErrInvalidBytecode is returned when a code word doesn't hold a compiled instruction.
+	ErrInvalidBytecode = errors.New("invalid bytecode")
+)
+
+// Compile resolves the labels of the program and encodes every instruction as an opcode followed
+// by its operand descriptors, the result is what's stored in the code words of the process.
+// An operand descriptor is the kind of the operand, the length of its text, a colon and the text,
+// so `assign x "hello world"` is encoded as `0 v1:x s11:hello world`.
+func Compile(code []string) ([]string, error) {
+	var parser parserManager
+	resolvedCode, err := ResolveLabels(code)
+	if err != nil {
+		return nil, err
+	}
+	bytecode := make([]string, len(resolvedCode))
+	for index, line := range resolvedCode {
+		instruction, err := parser.parse(line)
+		if err != nil {
+			return nil, err
+		}
+		if bytecode[index], err = encodeInstruction(instruction); err != nil {
+			return nil, err
+		}
+	}
+	return bytecode, nil
+}
+
+func encodeInstruction(instruction Instruction) (string, error) {
+	if _, isPresent := availableCommands[instruction.Command]; !isPresent {
+		return "", ErrInvalidCommand
+	}
+	var decoder decoderManager
+	var word strings.Builder
+	word.WriteString(strconv.Itoa(opcodeOf[instruction.Command]))
+	for _, arg := range instruction.Args {
+		descriptor := operand{kind: variableOperand, text: arg}
+		if _, isOperator := expressionOperators[arg]; isOperator {
+			descriptor = operand{kind: operatorOperand, text: strconv.Itoa(opcodeOf[arg])}
+		} else if decoder.isStringLiteral(arg) {
+			descriptor = operand{kind: stringOperand, text: arg[1 : len(arg)-1]}
+		} else if _, err := strconv.Atoi(arg); err == nil {
+			descriptor = operand{kind: integerOperand, text: arg}
+		}
+		fmt.Fprintf(&word, " %c%d:%s", descriptor.kind, len(descriptor.text), descriptor.text)
+	}
+	return word.String(), nil
+}
+
+// decodeInstruction splits a compiled code word into its opcode and typed operands.
+func decodeInstruction(word string) (compiledInstruction, error) {
+	opcodeText, operands, _ := strings.Cut(word, " ")
+	opcode, err := strconv.Atoi(opcodeText)
+	if err != nil || opcode < 0 || opcode >= len(opcodes) {
+		return compiledInstruction{}, ErrInvalidBytecode
+	}
+
+	instruction := compiledInstruction{opcode: opcode, operands: []operand{}}
+	for len(operands) > 0 {
+		kind := operandKind(operands[0])
+		lengthText, rest, isPresent := strings.Cut(operands[1:], ":")
+		length, err := strconv.Atoi(lengthText)
+		if !isPresent || err != nil || length < 0 || length > len(rest) {
+			return compiledInstruction{}, ErrInvalidBytecode
+		}
+		decoded := operand{kind: kind, text: rest[:length]}
+		operands = strings.TrimPrefix(rest[length:], " ")
+
+		switch kind {
+		case integerOperand, variableOperand, stringOperand:
+		case operatorOperand:
+			operatorCode, err := strconv.Atoi(decoded.text)
+			if err != nil || operatorCode < 0 || operatorCode >= len(opcodes) || operators[operatorCode].evaluate == nil {
+				return compiledInstruction{}, ErrInvalidBytecode
+			}
+			decoded.opcode = operatorCode
+		default:
+			return compiledInstruction{}, ErrInvalidBytecode
+		}
+		instruction.operands = append(instruction.operands, decoded)
+	}
+	return instruction, nil
+}
+
+// Variables returns the names of the variables the compiled instruction accesses, the resource named by
+// a resource command isn't a variable. It returns nil if the word doesn't hold a compiled instruction.
+func Variables(word string) []string {
+	instruction, err := decodeInstruction(word)
+	if err != nil {
+		return nil
+	}
+	operands := instruction.operands
+	if resourceCommands[opcodes[instruction.opcode]] && len(operands) > 0 {
+		operands = operands[1:]
+	}
+	names := []string{}
+	for _, operand := range operands {
+		if operand.kind == variableOperand {
+			names = append(names, operand.text)
+		}
+	}
+	return names
+}
@@ -0,0 +1,115 @@
+package interpreter
+
+import (
+	"reflect"
+	"testing"
+)
+
+// compile compiles the code of a test program and stops the test if it's invalid.
+func compile(t *testing.T, code []string) []string {
+	t.Helper()
+	bytecode, err := Compile(code)
+	if err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+	return bytecode
+}
+
+// decode compiles a single line and decodes its code word.
+func decode(t *testing.T, line string) compiledInstruction {
+	t.Helper()
+	instruction, err := decodeInstruction(compile(t, []string{line})[0])
+	if err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+	return instruction
+}
+
+func TestCompile(t *testing.T) {
+	t.Run("Test Encode Instructions", func(t *testing.T) {
+		code := []string{
+			"assign x \"hello world\"",
+			"label loop",
+			"add x x 1",
+			"assign y add input readFile \"a:b\"",
+			"jnz y loop",
+		}
+		expected := []string{
+			"0 v1:x s11:hello world",
+			"12 v1:x v1:x i1:1",
+			"0 v1:y o2:12 o2:19 o1:7 s3:a:b",
+			"11 v1:y i1:1",
+		}
+
+		actual := compile(t, code)
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("Unexpected mismatch: expected %q, found %q\n", expected, actual)
+		}
+	})
+
+	tests := map[string]struct {
+		code []string
+		err  error
+	}{
+		"unknown command":     {code: []string{"prnt x"}, err: ErrInvalidCommand},
+		"operator as command": {code: []string{"input"}, err: ErrInvalidCommand},
+		"undefined label":     {code: []string{"jmp end"}, err: ErrUndefinedLabel},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if _, err := Compile(test.code); err != test.err {
+				t.Fatalf("Expected %q, Found %q\n", test.err, err)
+			}
+		})
+	}
+}
+
+func TestDecodeInstruction(t *testing.T) {
+	t.Run("Test Decode Compiled Instructions", func(t *testing.T) {
+		tests := map[string]compiledInstruction{
+			"assign x \"hello  world\"": {opcode: 0, operands: []operand{
+				{kind: variableOperand, text: "x"}, {kind: stringOperand, text: "hello  world"},
+			}},
+			"print \"\"": {opcode: 1, operands: []operand{{kind: stringOperand, text: ""}}},
+			"assign y add input readFile \"a:b\"": {opcode: 0, operands: []operand{
+				{kind: variableOperand, text: "y"},
+				{kind: operatorOperand, text: "12", opcode: 12},
+				{kind: operatorOperand, text: "19", opcode: 19},
+				{kind: operatorOperand, text: "7", opcode: 7},
+				{kind: stringOperand, text: "a:b"},
+			}},
+			"semWait file": {opcode: 4, operands: []operand{{kind: variableOperand, text: "file"}}},
+		}
+		for line, expected := range tests {
+			if actual := decode(t, line); !reflect.DeepEqual(expected, actual) {
+				t.Fatalf("Unexpected mismatch: expected %v, found %v\n", expected, actual)
+			}
+		}
+	})
+
+	// opcode 0 is a command but not an operator
+	for _, word := range []string{"assign x 1", "99", "0 v9:x", "0 x1:x", "0 v1x", "0 o2:99", "0 o1:0"} {
+		t.Run("Test Invalid Word "+word, func(t *testing.T) {
+			if _, err := decodeInstruction(word); err != ErrInvalidBytecode {
+				t.Fatalf("Expected %q, Found %q\n", ErrInvalidBytecode, err)
+			}
+		})
+	}
+}
+
+func TestVariables(t *testing.T) {
+	tests := map[string][]string{
+		"assign x add y 1":    {"x", "y"},
+		"print \"x\"":         {},
+		"semWait file":        {},
+		"claim printer count": {"count"},
+	}
+	for line, expected := range tests {
+		if found := Variables(compile(t, []string{line})[0]); !reflect.DeepEqual(expected, found) {
+			t.Errorf("%v: expected %q, found %q", line, expected, found)
+		}
+	}
+	if found := Variables("assign x 1"); found != nil {
+		t.Errorf("expected nil, found %q", found)
+	}
+}
@@ -2,76 +2,174 @@ package interpreter
 
 import (
 	"errors"
-	"fmt"
-	"io"
 	"strconv"
 	"strings"
 
 	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
 )
 
-// symbolTable is a map representing the symbol table for variables.
-type symbolTable map[string]int
-
 // processId is a type representing the unique identifier for a process.
 type processId int
 
-type decoderManager struct {
-	processToSymbolTable map[processId]symbolTable
-}
+// decoderManager resolves the variables of a process through the symbol table stored in its data words.
+type decoderManager struct{}
 
 var (
 	// ErrType is a common error for a type error during value conversion.
 	ErrType = errors.New("type error")
 	// ErrUndefinedSymbol is a common error for accessing an undefined symbol in the symbol table.
 	ErrUndefinedSymbol = errors.New("undefined symbol")
+	// ErrTooManyVariables is returned when a process declares more variables than its data words can hold.
+	ErrTooManyVariables = memory.TooManyVariablesErr
 )
 
-func (d *decoderManager) getSymbolTable(process *memory.PCB) symbolTable {
-	// if not executed before init Process
-	_, isPresent := d.processToSymbolTable[processId(process.Id)]
-	if !isPresent {
-		d.processToSymbolTable[processId(process.Id)] = symbolTable{}
+// destinationCommands are the commands whose first argument is the variable the result is written to.
+var destinationCommands = map[string]bool{
+	"assign": true,
+	"add":    true,
+	"sub":    true,
+	"mul":    true,
+	"div":    true,
+	"mod":    true,
+	"eq":     true,
+	"lt":     true,
+}
+
+// resourceCommands are the commands whose first argument names a resource instead of a variable.
+var resourceCommands = map[string]bool{
+	"claim":     true,
+	"semInit":   true,
+	"semWait":   true,
+	"semSignal": true,
+}
+
+// checkOperands checks the number of arguments of the compiled instruction and the types known before
+// evaluation against the parameters of the command, so a malformed instruction fails before it declares
+// its destination or reads any input. The types of variables are only known once they're read.
+func (i *Interpreter) checkOperands(compiled compiledInstruction, command allowedCommand) error {
+	argTypes := []parameterType{}
+	operands := compiled.operands
+	if destinationCommands[command.command] && len(operands) > 0 {
+		if operands[0].kind != variableOperand {
+			return ErrInvalidArgumentType
+		}
+		argTypes = append(argTypes, INTEGER)
+		operands = operands[1:]
+	} else if resourceCommands[command.command] && len(operands) > 0 && operands[0].kind == variableOperand {
+		argTypes = append(argTypes, STRING)
+		operands = operands[1:]
+	}
+	for len(operands) > 0 {
+		var argType parameterType
+		var err error
+		if argType, operands, err = i.operandType(operands); err != nil {
+			return err
+		}
+		argTypes = append(argTypes, argType)
 	}
-	symTable := d.processToSymbolTable[processId(process.Id)]
-	return symTable
+	if len(argTypes) != len(command.parameters) {
+		return ErrInsufficientArguments
+	}
+	for index, argType := range argTypes {
+		if argType != ANY && !i.typeCheck(argType, command.parameters[index]) {
+			return ErrInvalidArgumentType
+		}
+	}
+	return nil
 }
 
-func (d *decoderManager) decodeArgs(instruction *Instruction, process *memory.PCB) error {
-	symTable := d.getSymbolTable(process)
-	if instruction.Command == "assign" {
+// operandType returns the type of the expression at the start of the compiled operands without evaluating it
+// and the remaining operands, ANY is returned when the type is only known at runtime.
+// The operands of an operator are checked against its parameters.
+func (i *Interpreter) operandType(operands []operand) (parameterType, []operand, error) {
+	if len(operands) == 0 {
+		return ANY, nil, ErrInsufficientArguments
+	}
+	current, rest := operands[0], operands[1:]
+	switch current.kind {
+	case integerOperand:
+		return INTEGER, rest, nil
+	case stringOperand:
+		return STRING, rest, nil
+	case variableOperand:
+		return ANY, rest, nil
+	}
+
+	op := operators[current.opcode]
+	for _, parameter := range op.parameters {
+		var argType parameterType
+		var err error
+		if argType, rest, err = i.operandType(rest); err != nil {
+			return ANY, nil, err
+		}
+		if argType != ANY && !i.typeCheck(argType, parameter) {
+			return ANY, nil, ErrInvalidArgumentType
+		}
+	}
+	return op.result, rest, nil
+}
+
+// decodeOperands evaluates the operands of the compiled instruction into the arguments of the command,
+// the destination variable is replaced with its address and a resource variable with the resource name.
+func (i *Interpreter) decodeOperands(compiled compiledInstruction, command allowedCommand, process *memory.PCB) (Instruction, error) {
+	instruction := Instruction{Command: command.command, Args: []string{}, argTypes: []parameterType{}}
+	operands := compiled.operands
+	if destinationCommands[command.command] && len(operands) > 0 {
+		if operands[0].kind != variableOperand {
+			return Instruction{}, ErrInvalidArgumentType
+		}
 		// Allocate the variable if not defined
-		d.allocateIfNotDefined(instruction.Args[0], symTable)
-		// Replace the destination operand with its address
-		instruction.Args[0] = strconv.Itoa(symTable[instruction.Args[0]])
+		address, err := process.DeclareVariable(operands[0].text)
+		if err != nil {
+			return Instruction{}, err
+		}
+		instruction.Args = append(instruction.Args, strconv.Itoa(address))
+		instruction.argTypes = append(instruction.argTypes, INTEGER)
+		operands = operands[1:]
+	} else if resourceCommands[command.command] && len(operands) > 0 && operands[0].kind == variableOperand {
+		// Pass the resource name as a string
+		instruction.Args = append(instruction.Args, operands[0].text)
+		instruction.argTypes = append(instruction.argTypes, STRING)
+		operands = operands[1:]
 	}
-	for index, arg := range instruction.Args {
-
-		if d.isSymbol(arg) {
-			address, isPresent := symTable[arg]
-			if !isPresent {
-				return ErrUndefinedSymbol
-			}
-			// get data of address of data
-			data, err := process.GetDataWord(address)
-			if err != nil {
-				return err
-			}
-			instruction.Args[index] = data
+	for len(operands) > 0 {
+		var value memory.Value
+		var err error
+		value, operands, err = i.evaluateOperand(operands, process)
+		if err != nil {
+			return Instruction{}, err
 		}
+		instruction.Args = append(instruction.Args, value.Data)
+		instruction.argTypes = append(instruction.argTypes, typeOf(value))
 	}
+	return instruction, nil
+}
 
-	return nil
+// variableValue returns the typed value of the variable with the given name.
+func (d *decoderManager) variableValue(name string, process *memory.PCB) (memory.Value, error) {
+	address, isPresent := process.FindVariable(name)
+	if !isPresent {
+		return memory.Value{}, ErrUndefinedSymbol
+	}
+	return process.GetVariable(address)
 }
 
-func (d *decoderManager) getValueType(token string, reader io.Reader) (value string, valueType parameterType, err error) {
-	if len(token) > 2 && strings.HasPrefix(token, "\"") && strings.HasSuffix(token, "\"") {
+// typeOf returns the parameter type of a typed value.
+func typeOf(value memory.Value) parameterType {
+	if value.Type == memory.IntegerValue {
+		return INTEGER
+	}
+	return STRING
+}
+
+func (d *decoderManager) isStringLiteral(token string) bool {
+	return len(token) >= 2 && strings.HasPrefix(token, "\"") && strings.HasSuffix(token, "\"")
+}
+
+func (d *decoderManager) getValueType(token string) (value string, valueType parameterType, err error) {
+	if d.isStringLiteral(token) {
 		croppedToken := token[1 : len(token)-1]
 		return croppedToken, STRING, nil
-	} else if token == "input" {
-		var data string
-		fmt.Fscanf(reader, "%s", &data)
-		return data, STRING, nil
 	} else if _, conversionErr := strconv.Atoi(token); conversionErr == nil {
 		return token, INTEGER, nil
 	} else {
@@ -80,10 +178,11 @@ func (d *decoderManager) getValueType(token string, reader io.Reader) (value str
 }
 
 func (d *decoderManager) isSymbol(token string) bool {
-	if token == "input" {
+	// expression operators are reserved, a variable can't be named after one
+	if _, isOperator := expressionOperators[token]; isOperator {
 		return false
 	}
-	if len(token) > 2 && strings.HasPrefix(token, "\"") && strings.HasSuffix(token, "\"") {
+	if d.isStringLiteral(token) {
 		return false
 	}
 	_, err := strconv.Atoi(token)
@@ -91,11 +190,3 @@ func (d *decoderManager) isSymbol(token string) bool {
 	return err != nil
 
 }
-
-func (d *decoderManager) allocateIfNotDefined(symbol string, symTable symbolTable) {
-	_, isPresent := symTable[symbol]
-	if !isPresent {
-		// Set new address
-		symTable[symbol] = len(symTable)
-	}
-}
@@ -1,9 +1,7 @@
 package interpreter
 
 import (
-	"bufio"
 	"reflect"
-	"strings"
 	"testing"
 
 	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
@@ -12,36 +10,31 @@ import (
 func TestGetValueType(t *testing.T) {
 	tests := map[string]struct {
 		token         string
-		userInput     string
 		expectedValue string
 		expectedType  parameterType
 		expectedErr   error
 	}{"Test Decode string literal token": {
 		token:         "\"This is String Literal\"",
-		userInput:     "",
 		expectedValue: "This is String Literal",
 		expectedType:  STRING,
 		expectedErr:   nil,
 	}, "Test Decode numeric literal token": {
 		token:         "120",
-		userInput:     "",
 		expectedValue: "120",
 		expectedType:  INTEGER,
 		expectedErr:   nil,
-	}, "Test Decode user input token": {
-		token:         "input",
-		userInput:     "data",
-		expectedValue: "data",
-		expectedType:  STRING,
-		expectedErr:   nil,
+	}, "Test Decode symbol token": {
+		token:         "x",
+		expectedValue: "",
+		expectedType:  ANY,
+		expectedErr:   ErrType,
 	},
 	}
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
-			i := NewInterpreter(&memory.MemoryManager{})
+			i := NewInterpreter(nil, nil, nil)
 
-			reader := bufio.NewReader(strings.NewReader(test.userInput))
-			actualValue, actualType, err := i.decoder.getValueType(test.token, reader)
+			actualValue, actualType, err := i.decoder.getValueType(test.token)
 			if err != test.expectedErr {
 				t.Fatalf("Unexpected Error Mismatch expected %q found %q\n", test.expectedErr, err)
 			}
@@ -65,10 +58,11 @@ func TestIsSymbol(t *testing.T) {
 		"numeric literal": {token: "120", expected: false},
 		"string literal":  {token: "\"String Literal Value\"", expected: false},
 		"correct symbol":  {token: "variable_name", expected: true},
+		"operator":        {token: "sub", expected: false},
 	}
 	for testName, test := range tests {
 		t.Run(testName, func(t *testing.T) {
-			i := NewInterpreter(&memory.MemoryManager{})
+			i := NewInterpreter(nil, nil, nil)
 			actual := i.decoder.isSymbol(test.token)
 			if test.expected != actual {
 				t.Fatalf("Unexpected result expected %t found %t\n", test.expected, actual)
@@ -78,36 +72,35 @@ func TestIsSymbol(t *testing.T) {
 
 }
 
-func TestAllocateIfNotDefined(t *testing.T) {
-	tests := map[string]struct {
-		symbol            string
-		inputSymbolTable  symbolTable
-		outputSymbolTable symbolTable
+func TestDecodeOperands(t *testing.T) {
+	memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+	process, _ := memoryManager.AddProcess([]string{"0 v1:x i1:1"})
+	i := NewInterpreter(nil, nil, nil)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		err      error
 	}{
-		"Test Undefined Symbol in Empty Symbol Table": {
-			symbol:            "x",
-			inputSymbolTable:  symbolTable{},
-			outputSymbolTable: symbolTable{"x": 0},
-		},
-		"Test Already Existing Symbol": {
-			symbol:            "x",
-			inputSymbolTable:  symbolTable{"x": 0},
-			outputSymbolTable: symbolTable{"x": 0},
-		},
-		"Test Undefined Symbol in nonEmpty Symbol Table": {
-			symbol:            "x",
-			inputSymbolTable:  symbolTable{"y": 0},
-			outputSymbolTable: symbolTable{"y": 0, "x": 1},
-		},
+		{name: "Test Declare Destination", input: "assign x 1", expected: []string{"0", "1"}},
+		{name: "Test Declare Second Destination", input: "assign y x", expected: []string{"1", "0"}},
+		{name: "Test Reuse Declared Destination", input: "add x y 2", expected: []string{"0", "0", "2"}},
+		{name: "Test Resource Name", input: "semWait x", expected: []string{"x"}},
+		{name: "Test Undefined Symbol", input: "print z", err: ErrUndefinedSymbol},
+		{name: "Test Declare Third Destination", input: "assign z 3", expected: []string{"2", "3"}},
+		{name: "Test Too Many Variables", input: "assign w 4", err: ErrTooManyVariables},
 	}
-	for testName, test := range tests {
-		t.Run(testName, func(t *testing.T) {
-			i := NewInterpreter(&memory.MemoryManager{})
-			symbol := test.symbol
-			symTable := test.inputSymbolTable
-			i.decoder.allocateIfNotDefined(symbol, symTable)
-			if !reflect.DeepEqual(symTable, test.outputSymbolTable) {
-				t.Fatalf("Unexpected Mismatch expected %q found %q\n", test.outputSymbolTable, symTable)
+	// the cases run in order since every one sees the variables the previous ones declared
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compiled := decode(t, test.input)
+			instruction, err := i.decodeOperands(compiled, commands[compiled.opcode], &process)
+			if err != test.err {
+				t.Fatalf("Unexpected Error Mismatch expected %q found %q\n", test.err, err)
+			}
+			if err == nil && !reflect.DeepEqual(instruction.Args, test.expected) {
+				t.Fatalf("Unexpected Mismatch expected %q found %q\n", test.expected, instruction.Args)
 			}
 		})
 	}
@@ -4,7 +4,7 @@ import (
 	"strconv"
 
 	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
-	"github.com/KhaledHegazy222/os-simulator/pkg/systemcalls"
+	"github.com/KhaledHegazy222/os-simulator/pkg/mutex"
 )
 
 type parameterType int8
@@ -25,80 +25,185 @@ const (
 	SUCCESS statusCode = 0
 	// ERROR represents the error status code after command execution.
 	ERROR statusCode = 1
+	// BLOCKED represents the status code of a command that completed but blocked the process.
+	BLOCKED statusCode = 2
+	// JUMPED represents the status code of a command that moved the program counter itself.
+	JUMPED statusCode = 3
 )
 
 type allowedCommand struct {
 	command    string
 	parameters []parameterType
-	run        func(instruction Instruction, process *memory.PCB) statusCode
+	run        func(i *Interpreter, instruction Instruction, process *memory.PCB) statusCode
 }
 
 var availableCommands = map[string]allowedCommand{
-	"assign":      {command: "assign", parameters: []parameterType{INTEGER, ANY}, run: runAssign},
-	"print":       {command: "print", parameters: []parameterType{ANY}, run: runPrint},
-	"semWait":     {command: "semWait", parameters: []parameterType{STRING}, run: runSemWait},
-	"semSignal":   {command: "semSignal", parameters: []parameterType{STRING}, run: runSemSignal},
-	"writeFile":   {command: "writeFile", parameters: []parameterType{STRING, ANY}, run: runWriteFile},
-	"readFile":    {command: "readFile", parameters: []parameterType{STRING}, run: runReadFile},
-	"printFromTo": {command: "printFromTo", parameters: []parameterType{INTEGER, INTEGER}, run: runPrintFromTo},
+	"assign":      {command: "assign", parameters: []parameterType{INTEGER, ANY}, run: (*Interpreter).runAssign},
+	"print":       {command: "print", parameters: []parameterType{ANY}, run: (*Interpreter).runPrint},
+	"claim":       {command: "claim", parameters: []parameterType{STRING, INTEGER}, run: (*Interpreter).runClaim},
+	"semInit":     {command: "semInit", parameters: []parameterType{STRING, INTEGER}, run: (*Interpreter).runSemInit},
+	"semWait":     {command: "semWait", parameters: []parameterType{STRING}, run: (*Interpreter).runSemWait},
+	"semSignal":   {command: "semSignal", parameters: []parameterType{STRING}, run: (*Interpreter).runSemSignal},
+	"writeFile":   {command: "writeFile", parameters: []parameterType{STRING, ANY}, run: (*Interpreter).runWriteFile},
+	"printFromTo": {command: "printFromTo", parameters: []parameterType{INTEGER, INTEGER}, run: (*Interpreter).runPrintFromTo},
+	"jmp":         {command: "jmp", parameters: []parameterType{INTEGER}, run: (*Interpreter).runJmp},
+	"jz":          {command: "jz", parameters: []parameterType{INTEGER, INTEGER}, run: (*Interpreter).runJz},
+	"jnz":         {command: "jnz", parameters: []parameterType{INTEGER, INTEGER}, run: (*Interpreter).runJnz},
+	"add":         integerCommand("add", sum),
+	"sub":         integerCommand("sub", difference),
+	"mul":         integerCommand("mul", product),
+	"div":         integerCommand("div", quotient),
+	"mod":         integerCommand("mod", remainder),
+	"eq":          integerCommand("eq", equal),
+	"lt":          integerCommand("lt", lessThan),
 }
 
-func runAssign(instruction Instruction, process *memory.PCB) statusCode {
+// integerCommand creates a command that applies the operation to two integer operands
+// and writes the result to the destination variable.
+func integerCommand(name string, apply func(a, b int) (int, error)) allowedCommand {
+	return allowedCommand{
+		command:    name,
+		parameters: []parameterType{INTEGER, INTEGER, INTEGER},
+		run: func(i *Interpreter, instruction Instruction, process *memory.PCB) statusCode {
+			destinationAddress, err := strconv.Atoi(instruction.Args[0])
+			if err != nil {
+				return ERROR
+			}
+			a, _ := strconv.Atoi(instruction.Args[1])
+			b, _ := strconv.Atoi(instruction.Args[2])
+			result, err := apply(a, b)
+			if err != nil {
+				return ERROR
+			}
+			if err := process.SetVariable(destinationAddress, memory.IntegerOf(result)); err != nil {
+				return ERROR
+			}
+			return SUCCESS
+		},
+	}
+}
+
+func (i *Interpreter) runAssign(instruction Instruction, process *memory.PCB) statusCode {
 	destinationAddress, err := strconv.Atoi(instruction.Args[0])
 	if err != nil {
 		return ERROR
 	}
-	value, err := strconv.Atoi(instruction.Args[1])
-	if err != nil {
+	value := memory.StringOf(instruction.Args[1])
+	if instruction.argTypes[1] == INTEGER {
+		value.Type = memory.IntegerValue
+	}
+	if err := process.SetVariable(destinationAddress, value); err != nil {
 		return ERROR
 	}
-	process.SetDataWord(destinationAddress, value)
 	return SUCCESS
 }
 
-func runPrint(instruction Instruction, process *memory.PCB) statusCode {
-	os := systemcalls.NewOS()
+func (i *Interpreter) runJmp(instruction Instruction, process *memory.PCB) statusCode {
+	return i.jump(instruction.Args[0], process)
+}
+
+func (i *Interpreter) runJz(instruction Instruction, process *memory.PCB) statusCode {
+	if instruction.Args[0] != "0" {
+		return SUCCESS
+	}
+	return i.jump(instruction.Args[1], process)
+}
+
+func (i *Interpreter) runJnz(instruction Instruction, process *memory.PCB) statusCode {
+	if instruction.Args[0] == "0" {
+		return SUCCESS
+	}
+	return i.jump(instruction.Args[1], process)
+}
+
+// jump moves the program counter to the given offset in the code of the process.
+func (i *Interpreter) jump(offset string, process *memory.PCB) statusCode {
+	location, err := strconv.Atoi(offset)
+	if err != nil {
+		return ERROR
+	}
+	if err := process.SetPC(location); err != nil {
+		return ERROR
+	}
+	return JUMPED
+}
+
+func (i *Interpreter) runPrint(instruction Instruction, process *memory.PCB) statusCode {
+	os := i.osFor(process)
 	data := instruction.Args[0]
 	os.PrintToStdOut(data)
 	return SUCCESS
 }
 
-func runSemWait(instruction Instruction, process *memory.PCB) statusCode {
+func (i *Interpreter) runClaim(instruction Instruction, process *memory.PCB) statusCode {
+	resource := instruction.Args[0]
+	instances, err := strconv.Atoi(instruction.Args[1])
+	if err != nil {
+		return ERROR
+	}
+	if err := i.mutex.Claim(resource, mutex.Process(process.Id), instances); err != nil {
+		return ERROR
+	}
 	return SUCCESS
 }
 
-func runSemSignal(instruction Instruction, process *memory.PCB) statusCode {
+func (i *Interpreter) runSemInit(instruction Instruction, process *memory.PCB) statusCode {
+	resource := instruction.Args[0]
+	count, err := strconv.Atoi(instruction.Args[1])
+	if err != nil {
+		return ERROR
+	}
+	if err := i.mutex.SemInit(resource, count); err != nil {
+		return ERROR
+	}
 	return SUCCESS
 }
 
-func runWriteFile(instruction Instruction, process *memory.PCB) statusCode {
-	os := systemcalls.NewOS()
-	path, data := instruction.Args[0], instruction.Args[1]
-
-	err := os.WriteToFile(path, data)
-	if err != nil {
+func (i *Interpreter) runSemWait(instruction Instruction, process *memory.PCB) statusCode {
+	resource := instruction.Args[0]
+	if err := i.mutex.CheckClaim(resource, mutex.Process(process.Id)); err != nil {
 		return ERROR
 	}
-	return SUCCESS
+	if i.mutex.SemWait(resource, mutex.Process(process.Id)) {
+		return SUCCESS
+	}
+	if err := i.scheduler.BlockProcess(process.Id); err != nil {
+		return ERROR
+	}
+	return BLOCKED
 }
 
-func runReadFile(instruction Instruction, process *memory.PCB) statusCode {
-	os := systemcalls.NewOS()
-	path := instruction.Args[0]
-	_, err := strconv.Atoi(instruction.Args[1])
-	if err != nil {
+func (i *Interpreter) runSemSignal(instruction Instruction, process *memory.PCB) statusCode {
+	resource := instruction.Args[0]
+	nextOwner, released := i.mutex.SemSignal(resource, mutex.Process(process.Id))
+	if !released {
 		return ERROR
 	}
+	wokenProcesses := i.mutex.GrantSafeRequests()
+	if nextOwner != mutex.NoProcess {
+		wokenProcesses = append(wokenProcesses, nextOwner)
+	}
+	for _, wokenProcess := range wokenProcesses {
+		if err := i.scheduler.UnBlockProcess(int(wokenProcess)); err != nil {
+			return ERROR
+		}
+	}
+	return SUCCESS
+}
 
-	_, err = os.ReadFile(path)
+func (i *Interpreter) runWriteFile(instruction Instruction, process *memory.PCB) statusCode {
+	os := i.osFor(process)
+	path, data := instruction.Args[0], instruction.Args[1]
+
+	err := os.WriteToFile(path, data)
 	if err != nil {
 		return ERROR
 	}
 	return SUCCESS
 }
 
-func runPrintFromTo(instruction Instruction, process *memory.PCB) statusCode {
-	os := systemcalls.NewOS()
+func (i *Interpreter) runPrintFromTo(instruction Instruction, process *memory.PCB) statusCode {
+	os := i.osFor(process)
 	start, err := strconv.Atoi(instruction.Args[0])
 	if err != nil {
 		return ERROR
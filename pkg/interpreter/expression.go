@@ -0,0 +1,168 @@
+package interpreter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+// expression is an instruction argument written in prefix notation,
+// either a single token or an operator applied to its operand expressions.
+type expression struct {
+	token    string
+	operands []expression
+}
+
+// operator is a sub-command that can be used as an argument and evaluates to a value.
+type operator struct {
+	parameters []parameterType
+	result     parameterType
+	evaluate   func(i *Interpreter, operands []memory.Value, process *memory.PCB) (memory.Value, error)
+}
+
+var expressionOperators = map[string]operator{
+	"input":    {parameters: []parameterType{}, result: ANY, evaluate: (*Interpreter).evaluateInput},
+	"readFile": {parameters: []parameterType{STRING}, result: STRING, evaluate: (*Interpreter).evaluateReadFile},
+	"add":      integerOperator(sum),
+	"sub":      integerOperator(difference),
+	"mul":      integerOperator(product),
+	"div":      integerOperator(quotient),
+	"mod":      integerOperator(remainder),
+	"eq":       integerOperator(equal),
+	"lt":       integerOperator(lessThan),
+}
+
+// parseExpression parses the expression at the start of the tokens and returns the remaining tokens.
+func parseExpression(tokens []string) (expression, []string, error) {
+	if len(tokens) == 0 {
+		return expression{}, nil, ErrInsufficientArguments
+	}
+	expr := expression{token: tokens[0]}
+	tokens = tokens[1:]
+	for operand := 0; operand < len(expressionOperators[expr.token].parameters); operand++ {
+		var operandExpr expression
+		var err error
+		operandExpr, tokens, err = parseExpression(tokens)
+		if err != nil {
+			return expression{}, nil, err
+		}
+		expr.operands = append(expr.operands, operandExpr)
+	}
+	return expr, tokens, nil
+}
+
+// evaluateOperand evaluates the expression at the start of the compiled operands and returns the remaining operands.
+func (i *Interpreter) evaluateOperand(operands []operand, process *memory.PCB) (memory.Value, []operand, error) {
+	if len(operands) == 0 {
+		return memory.Value{}, nil, ErrInsufficientArguments
+	}
+	current, rest := operands[0], operands[1:]
+	switch current.kind {
+	case integerOperand:
+		return memory.Value{Type: memory.IntegerValue, Data: current.text}, rest, nil
+	case stringOperand:
+		return memory.StringOf(current.text), rest, nil
+	case variableOperand:
+		value, err := i.decoder.variableValue(current.text, process)
+		return value, rest, err
+	}
+
+	op := operators[current.opcode]
+	values := make([]memory.Value, len(op.parameters))
+	for index := range values {
+		var err error
+		if values[index], rest, err = i.evaluateOperand(rest, process); err != nil {
+			return memory.Value{}, nil, err
+		}
+	}
+	value, err := op.evaluate(i, values, process)
+	return value, rest, err
+}
+
+// evaluateInput reads a word from the input of the process, numeric input is read as an integer.
+// Running out of input is a runtime error.
+func (i *Interpreter) evaluateInput(operands []memory.Value, process *memory.PCB) (memory.Value, error) {
+	data, err := i.osFor(process).ReadInput()
+	if err != nil {
+		return memory.Value{}, fmt.Errorf("%w: %w", ErrRunTimeError, err)
+	}
+	if number, err := strconv.Atoi(data); err == nil {
+		return memory.IntegerOf(number), nil
+	}
+	return memory.StringOf(data), nil
+}
+
+// evaluateReadFile returns the contents of the file at the given path.
+func (i *Interpreter) evaluateReadFile(operands []memory.Value, process *memory.PCB) (memory.Value, error) {
+	lines, err := i.osFor(process).ReadFile(operands[0].Data)
+	if err != nil {
+		return memory.Value{}, ErrRunTimeError
+	}
+	return memory.StringOf(strings.Join(lines, "\n")), nil
+}
+
+// integerOperator creates a binary operator on integer operands.
+func integerOperator(apply func(a, b int) (int, error)) operator {
+	return operator{
+		parameters: []parameterType{INTEGER, INTEGER},
+		result:     INTEGER,
+		evaluate: func(i *Interpreter, operands []memory.Value, process *memory.PCB) (memory.Value, error) {
+			numbers := make([]int, len(operands))
+			for index, operand := range operands {
+				if operand.Type != memory.IntegerValue {
+					return memory.Value{}, ErrInvalidArgumentType
+				}
+				numbers[index], _ = strconv.Atoi(operand.Data)
+			}
+			result, err := apply(numbers[0], numbers[1])
+			if err != nil {
+				return memory.Value{}, err
+			}
+			return memory.IntegerOf(result), nil
+		},
+	}
+}
+
+func sum(a, b int) (int, error) {
+	return a + b, nil
+}
+
+func difference(a, b int) (int, error) {
+	return a - b, nil
+}
+
+func product(a, b int) (int, error) {
+	return a * b, nil
+}
+
+func quotient(a, b int) (int, error) {
+	if b == 0 {
+		return 0, ErrRunTimeError
+	}
+	return a / b, nil
+}
+
+func remainder(a, b int) (int, error) {
+	if b == 0 {
+		return 0, ErrRunTimeError
+	}
+	return a % b, nil
+}
+
+// equal returns 1 if the operands are equal and 0 otherwise.
+func equal(a, b int) (int, error) {
+	if a == b {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// lessThan returns 1 if the first operand is less than the second and 0 otherwise.
+func lessThan(a, b int) (int, error) {
+	if a < b {
+		return 1, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,158 @@
+package interpreter
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := map[string]struct {
+		tokens   []string
+		expected expression
+		rest     []string
+	}{
+		"plain token": {
+			tokens:   []string{"x", "y"},
+			expected: expression{token: "x"},
+			rest:     []string{"y"},
+		},
+		"operator without operands": {
+			tokens:   []string{"input", "y"},
+			expected: expression{token: "input"},
+			rest:     []string{"y"},
+		},
+		"nested operators": {
+			tokens: []string{"add", "mul", "x", "2", "readFile", "a", "z"},
+			expected: expression{token: "add", operands: []expression{
+				{token: "mul", operands: []expression{{token: "x"}, {token: "2"}}},
+				{token: "readFile", operands: []expression{{token: "a"}}},
+			}},
+			rest: []string{"z"},
+		},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			expr, rest, err := parseExpression(test.tokens)
+			if err != nil {
+				t.Fatalf("Unexpected Error %q\n", err)
+			}
+			if !reflect.DeepEqual(test.expected, expr) {
+				t.Fatalf("Unexpected mismatch: expected %v, found %v\n", test.expected, expr)
+			}
+			if !reflect.DeepEqual(test.rest, rest) {
+				t.Fatalf("Unexpected mismatch: expected %q, found %q\n", test.rest, rest)
+			}
+		})
+	}
+
+	t.Run("missing operand", func(t *testing.T) {
+		if _, _, err := parseExpression([]string{"add", "1"}); err != ErrInsufficientArguments {
+			t.Fatalf("Expected %q, Found %q\n", ErrInsufficientArguments, err)
+		}
+	})
+}
+
+func TestEvaluateOperand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data")
+	if err := os.WriteFile(path, []byte("first line\nsecond line"), 0666); err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+
+	t.Run("Test Replace readFile With File Contents", func(t *testing.T) {
+		i := NewInterpreter(nil, nil, nil)
+		operands := decode(t, "print readFile \""+path+"\"").operands
+
+		value, rest, err := i.evaluateOperand(operands, &memory.PCB{})
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
+		if expected := memory.StringOf("first line\nsecond line"); value != expected || len(rest) != 0 {
+			t.Fatalf("Unexpected mismatch: expected %v, found %v and %v\n", expected, value, rest)
+		}
+	})
+
+	t.Run("Test readFile Of Missing File", func(t *testing.T) {
+		i := NewInterpreter(nil, nil, nil)
+		operands := decode(t, "print readFile \""+path+".missing\"").operands
+
+		if _, _, err := i.evaluateOperand(operands, &memory.PCB{}); err != ErrRunTimeError {
+			t.Fatalf("Expected %q, Found %q\n", ErrRunTimeError, err)
+		}
+	})
+}
+
+func TestEvaluateArithmeticExpressions(t *testing.T) {
+	memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+	i := NewInterpreter(nil, nil, nil)
+	process, _ := memoryManager.AddProcess(compile(t, []string{"assign x 7"}))
+	if err := i.Execute(&process); err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+
+	tests := map[string]struct {
+		line     string
+		expected []string
+		err      error
+	}{
+		"add":              {line: "assign y add x 1", expected: []string{"1", "8"}},
+		"nested":           {line: "assign y sub mul x 2 div x 2", expected: []string{"1", "11"}},
+		"mod":              {line: "print mod x 4", expected: []string{"3"}},
+		"division by zero": {line: "print div x 0", err: ErrRunTimeError},
+		"string operand":   {line: "print add x \"1\"", err: ErrInvalidArgumentType},
+		"undefined symbol": {line: "print add z 1", err: ErrUndefinedSymbol},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			compiled := decode(t, test.line)
+			instruction, err := i.decodeOperands(compiled, commands[compiled.opcode], &process)
+			if err != test.err {
+				t.Fatalf("Expected %v, Found %v\n", test.err, err)
+			}
+			if err == nil && !reflect.DeepEqual(test.expected, instruction.Args) {
+				t.Fatalf("Unexpected mismatch: expected %q, found %q\n", test.expected, instruction.Args)
+			}
+		})
+	}
+}
+
+func TestExecuteInputExpression(t *testing.T) {
+	memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+	i := NewInterpreter(nil, nil, nil)
+	process, _ := memoryManager.AddProcess(compile(t, []string{"assign x input", "assign y input", "assign z add y 1"}))
+	i.SetProcessIO(process.Id, strings.NewReader("hello\n41\n"), nil)
+
+	for step := 0; step < 3; step++ {
+		if err := i.Execute(&process); err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
+	}
+
+	expected := []memory.Value{memory.StringOf("hello"), memory.IntegerOf(41), memory.IntegerOf(42)}
+	for address, expectedValue := range expected {
+		value, _ := process.GetVariable(address)
+		if value != expectedValue {
+			t.Fatalf("Expected %v, Found %v\n", expectedValue, value)
+		}
+	}
+}
+
+func TestExecuteInputAfterEndOfInput(t *testing.T) {
+	memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+	i := NewInterpreter(nil, nil, nil)
+	process, _ := memoryManager.AddProcess(compile(t, []string{"assign x input", "assign y input"}))
+	i.SetProcessIO(process.Id, strings.NewReader("7\n"), nil)
+
+	if err := i.Execute(&process); err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+	if err := i.Execute(&process); !errors.Is(err, ErrRunTimeError) || !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected %q and %q, Found %q\n", ErrRunTimeError, io.EOF, err)
+	}
+}
@@ -4,23 +4,29 @@ package interpreter
 
 import (
 	"errors"
-	"os"
+	"io"
 
 	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+	"github.com/KhaledHegazy222/os-simulator/pkg/mutex"
+	"github.com/KhaledHegazy222/os-simulator/pkg/scheduler"
+	"github.com/KhaledHegazy222/os-simulator/pkg/systemcalls"
 )
 
 // Interpreter represents the interpreter for processing instructions.
 type Interpreter struct {
-	memory               *memory.MemoryManager
-	processToSymbolTable map[processId]symbolTable
-	decoder              *decoderManager
-	parser               *parserManager
+	scheduler   *scheduler.Scheduler
+	mutex       *mutex.Mutex
+	os          *systemcalls.OS
+	processToOS map[processId]*systemcalls.OS
+	decoder     *decoderManager
 }
 
 // Instruction represents a single instruction with a command and its arguments.
 type Instruction struct {
 	Command string
 	Args    []string
+	// argTypes holds the type of every argument once the types are matched
+	argTypes []parameterType
 }
 
 var (
@@ -36,16 +42,19 @@ var (
 	ErrRunTimeError = errors.New("runtime error")
 )
 
-// NewInterpreter creates a new Interpreter instance with the provided memory manager.
-func NewInterpreter(memoryManager *memory.MemoryManager) Interpreter {
-	processToSymbolTable := map[processId]symbolTable{}
-	decoder := &decoderManager{processToSymbolTable: processToSymbolTable}
-	parser := &parserManager{}
+// NewInterpreter creates a new Interpreter instance with the scheduler that blocks and unblocks processes,
+// the mutex that guards resources and the os that processes read input from and print to, the standard input and output are used if it's nil.
+func NewInterpreter(processScheduler *scheduler.Scheduler, processMutex *mutex.Mutex, os *systemcalls.OS) Interpreter {
+	if os == nil {
+		os = systemcalls.NewOS()
+	}
+	decoder := &decoderManager{}
 	return Interpreter{
-		memory:               memoryManager,
-		processToSymbolTable: processToSymbolTable,
-		decoder:              decoder,
-		parser:               parser,
+		scheduler:   processScheduler,
+		mutex:       processMutex,
+		os:          os,
+		processToOS: map[processId]*systemcalls.OS{},
+		decoder:     decoder,
 	}
 }
 
@@ -56,60 +65,80 @@ func (i *Interpreter) Execute(process *memory.PCB) error {
 		return ErrBlockedProcess
 	}
 	// Get the next Instruction
-	nextLine, err := process.GetNextInstruction()
+	word, err := process.GetNextInstruction()
 	if err != nil {
 		return err
 	}
 
-	// Parse Instruction
-	instruction := i.parser.parse(nextLine)
+	// Decode the compiled Instruction
+	compiled, err := decodeInstruction(word)
+	if err != nil {
+		return err
+	}
 
 	// Find Matched Command
-	command, err := i.matchCommand(instruction)
+	command, err := i.matchCommand(compiled.opcode)
 	if err != nil {
 		return err
 	}
 
-	// Decode Instruction arguments
-	if err = i.decoder.decodeArgs(&instruction, process); err != nil {
+	// Check the arguments before any of them is declared or evaluated
+	if err = i.checkOperands(compiled, command); err != nil {
 		return err
 	}
 
-	if err = i.matchTypes(&instruction, command); err != nil {
+	// Evaluate the operands into the arguments of the command
+	instruction, err := i.decodeOperands(compiled, command, process)
+	if err != nil {
+		return err
+	}
+
+	if err = i.matchTypes(instruction, command); err != nil {
 		return err
 	}
 
 	// Execute Instruction
-	status := command.run(instruction, process)
-	if status != SUCCESS {
+	status := command.run(i, instruction, process)
+	switch status {
+	case SUCCESS, BLOCKED:
+		process.IncrementPC()
+	case JUMPED:
+		// the program counter already points to the target instruction
+	default:
 		return ErrRunTimeError
 	}
-
-	process.IncrementPC()
 	return nil
 }
 
-func (i *Interpreter) matchCommand(instruction Instruction) (allowedCommand, error) {
-	matchedCommand, isPresent := availableCommands[instruction.Command]
-	if !isPresent {
-		return allowedCommand{}, ErrInvalidCommand
-	}
+// SetProcessIO redirects the input and output of the process with the given id.
+func (i *Interpreter) SetProcessIO(pid int, reader io.Reader, writer io.Writer) {
+	i.processToOS[processId(pid)] = systemcalls.NewOSWithIO(reader, writer)
+}
 
-	if len(matchedCommand.parameters) != len(instruction.Args) {
-		return allowedCommand{}, ErrInsufficientArguments
+// osFor returns the os the given process reads input from and prints to.
+func (i *Interpreter) osFor(process *memory.PCB) *systemcalls.OS {
+	if os, isPresent := i.processToOS[processId(process.Id)]; isPresent {
+		return os
 	}
+	if i.os == nil {
+		i.os = systemcalls.NewOS()
+	}
+	return i.os
+}
 
-	return matchedCommand, nil
+func (i *Interpreter) matchCommand(opcode int) (allowedCommand, error) {
+	if opcode < 0 || opcode >= len(commands) || commands[opcode].run == nil {
+		return allowedCommand{}, ErrInvalidCommand
+	}
+	return commands[opcode], nil
 }
 
-func (i *Interpreter) matchTypes(instruction *Instruction, command allowedCommand) error {
-	for index, arg := range instruction.Args {
-		value, valueType, err := i.decoder.getValueType(arg, os.Stdin)
-		if err != nil {
-			return err
-		}
-		instruction.Args[index] = value
-		if !i.typeCheck(valueType, command.parameters[index]) {
+func (i *Interpreter) matchTypes(instruction Instruction, command allowedCommand) error {
+	if len(command.parameters) != len(instruction.argTypes) {
+		return ErrInsufficientArguments
+	}
+	for index, argType := range instruction.argTypes {
+		if !i.typeCheck(argType, command.parameters[index]) {
 			return ErrInvalidArgumentType
 		}
 	}
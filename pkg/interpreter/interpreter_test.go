@@ -1,16 +1,19 @@
 package interpreter
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+	"github.com/KhaledHegazy222/os-simulator/pkg/mutex"
+	"github.com/KhaledHegazy222/os-simulator/pkg/scheduler"
 )
 
 func TestMatchCommand(t *testing.T) {
 	t.Run("Test Match Existing Command", func(t *testing.T) {
-		i := NewInterpreter(&memory.MemoryManager{})
+		i := NewInterpreter(nil, nil, nil)
 		expected := availableCommands["assign"]
-		actual, err := i.matchCommand(Instruction{Command: "assign", Args: []string{"x", "11"}})
+		actual, err := i.matchCommand(opcodeOf["assign"])
 
 		if err != nil {
 			t.Fatalf("Unexpected Error %q\n", err)
@@ -20,13 +23,13 @@ func TestMatchCommand(t *testing.T) {
 			t.Fatalf("Unexpected mismatch: expected %q, found %q\n", expected.command, actual.command)
 		}
 	})
-	t.Run("Test Match Existing Command With Insufficient Args Number", func(t *testing.T) {
-		i := NewInterpreter(&memory.MemoryManager{})
+	t.Run("Test Operator Opcode", func(t *testing.T) {
+		i := NewInterpreter(nil, nil, nil)
 		expected := allowedCommand{}
-		actual, err := i.matchCommand(Instruction{Command: "assign", Args: []string{"x"}})
+		actual, err := i.matchCommand(opcodeOf["input"])
 
-		if err != ErrInsufficientArguments {
-			t.Fatalf("Expected %q, Found %q\n", ErrInsufficientArguments, err)
+		if err != ErrInvalidCommand {
+			t.Fatalf("Expected %q, Found %q\n", ErrInvalidCommand, err)
 		}
 
 		if expected.command != actual.command {
@@ -35,12 +38,12 @@ func TestMatchCommand(t *testing.T) {
 
 	})
 	t.Run("Test Invalid Command", func(t *testing.T) {
-		i := NewInterpreter(&memory.MemoryManager{})
+		i := NewInterpreter(nil, nil, nil)
 		expected := allowedCommand{}
-		actual, err := i.matchCommand(Instruction{Command: "RandomCommand", Args: []string{"x"}})
+		actual, err := i.matchCommand(len(opcodes))
 
 		if err != ErrInvalidCommand {
-			t.Fatalf("Expected %q, Found %q\n", ErrInsufficientArguments, err)
+			t.Fatalf("Expected %q, Found %q\n", ErrInvalidCommand, err)
 		}
 
 		if expected.command != actual.command {
@@ -54,9 +57,10 @@ func TestMatchTypes(t *testing.T) {
 	t.Run("Test Matching Command types", func(t *testing.T) {
 
 		i := Interpreter{}
-		instruction := &Instruction{
-			Command: "assign",
-			Args:    []string{"42", "\"string data\""},
+		instruction := Instruction{
+			Command:  "assign",
+			Args:     []string{"42", "string data"},
+			argTypes: []parameterType{INTEGER, STRING},
 		}
 		command := allowedCommand{
 			command:    "assign",
@@ -72,9 +76,10 @@ func TestMatchTypes(t *testing.T) {
 	})
 	t.Run("Test Mismatching Command types", func(t *testing.T) {
 		i := Interpreter{}
-		instruction := &Instruction{
-			Command: "assign",
-			Args:    []string{"42", "32"},
+		instruction := Instruction{
+			Command:  "assign",
+			Args:     []string{"42", "32"},
+			argTypes: []parameterType{INTEGER, INTEGER},
 		}
 		command := allowedCommand{
 			command:    "assign",
@@ -88,6 +93,20 @@ func TestMatchTypes(t *testing.T) {
 		}
 
 	})
+	t.Run("Test Insufficient Args Number", func(t *testing.T) {
+		i := Interpreter{}
+		instruction := Instruction{
+			Command:  "assign",
+			Args:     []string{"0"},
+			argTypes: []parameterType{INTEGER},
+		}
+
+		err := i.matchTypes(instruction, availableCommands["assign"])
+		if err != ErrInsufficientArguments {
+			t.Errorf("Error: expected %v, got %v", ErrInsufficientArguments, err)
+		}
+
+	})
 
 }
 
@@ -113,3 +132,192 @@ func TestTypeCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteSemaphores(t *testing.T) {
+	memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+	processScheduler := scheduler.NewScheduler()
+	processMutex := mutex.NewMutex()
+	i := NewInterpreter(processScheduler, &processMutex, nil)
+
+	first, _ := memoryManager.AddProcess(compile(t, []string{"semWait file", "semSignal file"}))
+	second, _ := memoryManager.AddProcess(compile(t, []string{"semWait file", "semSignal file"}))
+	processScheduler.AddToReadyQueue(&first)
+	processScheduler.AddToReadyQueue(&second)
+
+	if err := i.Execute(&first); err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+
+	// second process is blocked and continues after the instruction once it owns the resource
+	pc := second.PC
+	if err := i.Execute(&second); err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+	if second.State != memory.Blocked {
+		t.Fatalf("Expected %q, found %q\n", memory.Blocked, second.State)
+	}
+	if second.PC != pc+1 {
+		t.Fatalf("Expected pc %d, found %d\n", pc+1, second.PC)
+	}
+
+	// releasing the resource hands it to the second process and unblocks it
+	if err := i.Execute(&first); err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+	if second.State != memory.Ready {
+		t.Fatalf("Expected %q, found %q\n", memory.Ready, second.State)
+	}
+	if err := i.Execute(&second); err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+}
+
+func TestExecuteSemSignalNotOwner(t *testing.T) {
+	memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+	processScheduler := scheduler.NewScheduler()
+	processMutex := mutex.NewMutex()
+	i := NewInterpreter(processScheduler, &processMutex, nil)
+
+	process, _ := memoryManager.AddProcess(compile(t, []string{"semSignal file"}))
+	processScheduler.AddToReadyQueue(&process)
+
+	if err := i.Execute(&process); err != ErrRunTimeError {
+		t.Fatalf("Expected %q, Found %q\n", ErrRunTimeError, err)
+	}
+}
+
+func TestCompileReadFileStatement(t *testing.T) {
+	if _, err := Compile([]string{"readFile \"data\""}); err != ErrInvalidCommand {
+		t.Fatalf("Expected %q, Found %q\n", ErrInvalidCommand, err)
+	}
+}
+
+func TestExecuteTypedAssign(t *testing.T) {
+	memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+	i := NewInterpreter(nil, nil, nil)
+	process, _ := memoryManager.AddProcess(compile(t, []string{
+		"assign x \"hello\"",
+		"assign y \"42\"",
+		"assign z 42",
+		"assign x y",
+	}))
+
+	for step := 0; step < 4; step++ {
+		if err := i.Execute(&process); err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
+	}
+
+	// the string copied from y keeps its type even though it looks like a number
+	expected := []memory.Value{memory.StringOf("42"), memory.StringOf("42"), memory.IntegerOf(42)}
+	for address, expectedValue := range expected {
+		value, err := process.GetVariable(address)
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
+		if value != expectedValue {
+			t.Fatalf("Expected %v, Found %v\n", expectedValue, value)
+		}
+	}
+}
+
+func TestExecuteStringVariableTypeCheck(t *testing.T) {
+	memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+	i := NewInterpreter(nil, nil, nil)
+	process, _ := memoryManager.AddProcess(compile(t, []string{"assign x \"1\"", "printFromTo x 3"}))
+
+	if err := i.Execute(&process); err != nil {
+		t.Fatalf("Unexpected Error %q\n", err)
+	}
+	if err := i.Execute(&process); err != ErrInvalidArgumentType {
+		t.Fatalf("Expected %q, Found %q\n", ErrInvalidArgumentType, err)
+	}
+}
+
+func TestExecuteChecksArgumentsBeforeEvaluating(t *testing.T) {
+	tests := map[string]struct {
+		line string
+		err  error
+	}{
+		"extra argument":        {line: "assign x input 1", err: ErrInsufficientArguments},
+		"missing argument":      {line: "add x input", err: ErrInsufficientArguments},
+		"string argument":       {line: "printFromTo input \"a\"", err: ErrInvalidArgumentType},
+		"string operand":        {line: "assign x add input \"a\"", err: ErrInvalidArgumentType},
+		"string operator value": {line: "add x readFile \"a.txt\" input", err: ErrInvalidArgumentType},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+			i := NewInterpreter(nil, nil, nil)
+			process, _ := memoryManager.AddProcess(compile(t, []string{test.line}))
+			i.SetProcessIO(process.Id, strings.NewReader("5\n"), nil)
+
+			if err := i.Execute(&process); err != test.err {
+				t.Fatalf("Expected %q, Found %q\n", test.err, err)
+			}
+			if _, isDeclared := process.FindVariable("x"); isDeclared {
+				t.Fatalf("Expected x to be undeclared\n")
+			}
+			if data, err := i.osFor(&process).ReadInput(); err != nil || data != "5" {
+				t.Fatalf("Expected the input to be unread, found %q %v\n", data, err)
+			}
+		})
+	}
+}
+
+func TestExecuteArithmetic(t *testing.T) {
+	tests := map[string]struct {
+		code     []string
+		expected memory.Value
+	}{
+		"add":             {code: []string{"assign a 7", "add x a 5"}, expected: memory.IntegerOf(12)},
+		"sub":             {code: []string{"assign a 7", "sub x a 5"}, expected: memory.IntegerOf(2)},
+		"mul":             {code: []string{"assign a 7", "mul x a 5"}, expected: memory.IntegerOf(35)},
+		"div":             {code: []string{"assign a 7", "div x a 2"}, expected: memory.IntegerOf(3)},
+		"mod":             {code: []string{"assign a 7", "mod x a 2"}, expected: memory.IntegerOf(1)},
+		"eq true":         {code: []string{"assign a 7", "eq x a 7"}, expected: memory.IntegerOf(1)},
+		"eq false":        {code: []string{"assign a 7", "eq x a 5"}, expected: memory.IntegerOf(0)},
+		"lt true":         {code: []string{"assign a 7", "lt x 5 a"}, expected: memory.IntegerOf(1)},
+		"lt false":        {code: []string{"assign a 7", "lt x a 5"}, expected: memory.IntegerOf(0)},
+		"in place":        {code: []string{"assign x 7", "add x x 1"}, expected: memory.IntegerOf(8)},
+		"with expression": {code: []string{"assign a 7", "add x a mul a 2"}, expected: memory.IntegerOf(21)},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+			i := NewInterpreter(nil, nil, nil)
+			process, _ := memoryManager.AddProcess(compile(t, test.code))
+
+			for range test.code {
+				if err := i.Execute(&process); err != nil {
+					t.Fatalf("Unexpected Error %q\n", err)
+				}
+			}
+			address, _ := process.FindVariable("x")
+			value, _ := process.GetVariable(address)
+			if value != test.expected {
+				t.Fatalf("Expected %v, Found %v\n", test.expected, value)
+			}
+		})
+	}
+
+	t.Run("Test Division By Zero", func(t *testing.T) {
+		memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+		i := NewInterpreter(nil, nil, nil)
+		process, _ := memoryManager.AddProcess(compile(t, []string{"div x 1 0"}))
+
+		if err := i.Execute(&process); err != ErrRunTimeError {
+			t.Fatalf("Expected %q, Found %q\n", ErrRunTimeError, err)
+		}
+	})
+
+	t.Run("Test String Operand", func(t *testing.T) {
+		memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+		i := NewInterpreter(nil, nil, nil)
+		process, _ := memoryManager.AddProcess(compile(t, []string{"add x \"1\" 2"}))
+
+		if err := i.Execute(&process); err != ErrInvalidArgumentType {
+			t.Fatalf("Expected %q, Found %q\n", ErrInvalidArgumentType, err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package interpreter
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrUndefinedLabel is returned when a jump targets a label that is never declared.
+	ErrUndefinedLabel = errors.New("undefined label")
+	// ErrDuplicateLabel is returned when a label is declared more than once.
+	ErrDuplicateLabel = errors.New("label is already declared")
+)
+
+// jumpCommands maps every jump command to the number of expressions before its label argument.
+var jumpCommands = map[string]int{
+	"jmp": 0,
+	"jz":  1,
+	"jnz": 1,
+}
+
+// labelIndex returns the position of the label in the arguments of the jump command, the expressions
+// before it are parsed so conditions like `jz eq x 3 loop` are skipped whatever their length.
+// The position is past the last argument if the label is missing, false is returned for other commands.
+func labelIndex(command string, args []string) (int, bool) {
+	expressions, isJump := jumpCommands[command]
+	if !isJump {
+		return 0, false
+	}
+	rest := args
+	for expression := 0; expression < expressions; expression++ {
+		var err error
+		if _, rest, err = parseExpression(rest); err != nil {
+			return len(args), true
+		}
+	}
+	return len(args) - len(rest), true
+}
+
+// ResolveLabels removes the `label name` declarations, the blank lines and the comments from the code
+// and replaces the label of every jump with the offset of the instruction it points to in the code of the process.
+func ResolveLabels(code []string) ([]string, error) {
+	var parser parserManager
+	labels := map[string]int{}
+	instructions := make([]string, 0, len(code))
+	for _, line := range code {
+		instruction, err := parser.parse(line)
+		if err != nil {
+			return nil, err
+		}
+		if instruction.Command == "" {
+			continue
+		}
+		if instruction.Command != "label" {
+			instructions = append(instructions, line)
+			continue
+		}
+		if len(instruction.Args) != 1 {
+			return nil, ErrInsufficientArguments
+		}
+		if _, isDeclared := labels[instruction.Args[0]]; isDeclared {
+			return nil, ErrDuplicateLabel
+		}
+		labels[instruction.Args[0]] = len(instructions)
+	}
+
+	for index, line := range instructions {
+		tokens, _ := parser.tokenize(line)
+		instruction, _ := parser.parse(line)
+		argIndex, isJump := labelIndex(instruction.Command, instruction.Args)
+		if !isJump {
+			continue
+		}
+		if argIndex >= len(instruction.Args) {
+			return nil, ErrInsufficientArguments
+		}
+		offset, isDeclared := labels[instruction.Args[argIndex]]
+		if !isDeclared {
+			return nil, ErrUndefinedLabel
+		}
+		// Only the label is replaced so the rest of the line, string literals included, is kept as written.
+		label := tokens[argIndex+1]
+		start := label.column - 1
+		instructions[index] = line[:start] + strconv.Itoa(offset) + line[start+len(label.text):]
+	}
+	return instructions, nil
+}
@@ -0,0 +1,131 @@
+package interpreter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+func TestResolveLabels(t *testing.T) {
+	t.Run("Test Replace Labels With Offsets", func(t *testing.T) {
+		code := []string{
+			"# count down",
+			"label start",
+			"",
+			"assign x input // first instruction",
+			"jz x end",
+			"label loop",
+			"print \"not zero\"",
+			"jnz x loop",
+			"jmp start",
+			"label end",
+		}
+		expected := []string{
+			"assign x input // first instruction",
+			"jz x 5",
+			"print \"not zero\"",
+			"jnz x 2",
+			"jmp 0",
+		}
+
+		actual, err := ResolveLabels(code)
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("Unexpected mismatch: expected %q, found %q\n", expected, actual)
+		}
+	})
+
+	t.Run("Test Jump On Expression", func(t *testing.T) {
+		code := []string{"label loop", "add x x 1", "jz eq x 3 loop", "jnz lt x add 2 3 loop"}
+		expected := []string{"add x x 1", "jz eq x 3 0", "jnz lt x add 2 3 0"}
+
+		actual, err := ResolveLabels(code)
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("Unexpected mismatch: expected %q, found %q\n", expected, actual)
+		}
+	})
+
+	t.Run("Test Jump On Escaped String", func(t *testing.T) {
+		code := []string{"label loop", `jz eq x "a\"b c" loop # compare`, `jnz eq "tab\t" y loop`}
+		expected := []string{`jz eq x "a\"b c" 0 # compare`, `jnz eq "tab\t" y 0`}
+
+		actual, err := ResolveLabels(code)
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("Unexpected mismatch: expected %q, found %q\n", expected, actual)
+		}
+	})
+
+	tests := map[string]struct {
+		code []string
+		err  error
+	}{
+		"undefined label":          {code: []string{"jmp end"}, err: ErrUndefinedLabel},
+		"duplicate label":          {code: []string{"label a", "label a"}, err: ErrDuplicateLabel},
+		"label without name":       {code: []string{"label"}, err: ErrInsufficientArguments},
+		"jump without label":       {code: []string{"jz x"}, err: ErrInsufficientArguments},
+		"expression without label": {code: []string{"jz eq x 3"}, err: ErrInsufficientArguments},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if _, err := ResolveLabels(test.code); err != test.err {
+				t.Fatalf("Expected %q, Found %q\n", test.err, err)
+			}
+		})
+	}
+}
+
+func TestExecuteJumps(t *testing.T) {
+	tests := map[string]struct {
+		instruction string
+		x           string
+		jumped      bool
+	}{
+		"jmp":         {instruction: "jmp start", x: "0", jumped: true},
+		"jz on zero":  {instruction: "jz x start", x: "0", jumped: true},
+		"jz on one":   {instruction: "jz x start", x: "1", jumped: false},
+		"jnz on zero": {instruction: "jnz x start", x: "0", jumped: false},
+		"jnz on one":  {instruction: "jnz x start", x: "1", jumped: true},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+			i := NewInterpreter(nil, nil, nil)
+			process, _ := memoryManager.AddProcess(compile(t, []string{"label start", "assign x " + test.x, test.instruction}))
+			start := process.PC
+
+			for step := 0; step < 2; step++ {
+				if err := i.Execute(&process); err != nil {
+					t.Fatalf("Unexpected Error %q\n", err)
+				}
+			}
+			expectedPC := start + 2
+			if test.jumped {
+				expectedPC = start
+			}
+			if process.PC != expectedPC {
+				t.Fatalf("Expected pc %d, Found %d\n", expectedPC, process.PC)
+			}
+		})
+	}
+
+	t.Run("Test Jump Outside The Code", func(t *testing.T) {
+		memoryManager, _ := memory.NewMemoryManager(memory.DefaultConfig())
+		i := NewInterpreter(nil, nil, nil)
+		// compiled programs only jump to labels, so the word is encoded by hand
+		word, _ := encodeInstruction(Instruction{Command: "jmp", Args: []string{"2"}})
+		process, _ := memoryManager.AddProcess([]string{word})
+
+		if err := i.Execute(&process); err != ErrRunTimeError {
+			t.Fatalf("Expected %q, Found %q\n", ErrRunTimeError, err)
+		}
+	})
+}
@@ -1,32 +1,126 @@
 package interpreter
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 type parserManager struct{}
 
-func (p *parserManager) parse(line string) Instruction {
-	tokens := strings.Split(line, " ")
-	command := tokens[0]
-	args := make([]string, 0)
-	isStringLiteral := false
-	for i := 1; i < len(tokens); i++ {
-		if isStringLiteral {
-			lastArg := args[len(args)-1]
-			lastArg += " " + tokens[i]
-			if strings.HasSuffix(tokens[i], "\"") {
-				isStringLiteral = false
+// token is a word of an instruction and the column it starts at.
+type token struct {
+	text   string
+	column int
+}
+
+// SyntaxError reports a malformed instruction at the given column.
+type SyntaxError struct {
+	Column int
+	Err    error
+}
+
+var (
+	// ErrUnterminatedString is returned when a string literal isn't closed before the end of the line.
+	ErrUnterminatedString = errors.New("unterminated string literal")
+	// ErrInvalidEscape is returned when a backslash in a string literal isn't followed by a known escape.
+	ErrInvalidEscape = errors.New("invalid escape sequence")
+)
+
+// escapeSequences maps the character after a backslash in a string literal to the character it stands for.
+var escapeSequences = map[byte]byte{
+	'"':  '"',
+	'\\': '\\',
+	'n':  '\n',
+	't':  '\t',
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %v", e.Column, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+func (p *parserManager) parse(line string) (Instruction, error) {
+	tokens, err := p.tokenize(line)
+	if err != nil {
+		return Instruction{}, err
+	}
+	if len(tokens) == 0 {
+		return Instruction{Args: []string{}}, nil
+	}
+	args := make([]string, 0, len(tokens)-1)
+	for _, arg := range tokens[1:] {
+		args = append(args, arg.text)
+	}
+	return Instruction{Command: tokens[0].text, Args: args}, nil
+}
+
+// tokenize splits the line on spaces and tabs into words and string literals.
+// A string literal keeps its content exactly, with the escape sequences \", \\, \n and \t replaced
+// by the characters they stand for, and its token is the content between double quotes.
+// A word starting with `#` or `//` outside a string literal starts a comment that runs to the end of the line.
+func (p *parserManager) tokenize(line string) ([]token, error) {
+	tokens := []token{}
+	for index := 0; index < len(line); {
+		switch {
+		case p.isSeparator(line[index]):
+			index++
+		case line[index] == '"':
+			literal, end, err := p.scanStringLiteral(line, index)
+			if err != nil {
+				return nil, err
 			}
-			args[len(args)-1] = lastArg
-		} else {
-			if len(tokens[i]) == 0 {
-				continue
+			tokens = append(tokens, token{text: literal, column: index + 1})
+			index = end
+		default:
+			end := index
+			for end < len(line) && !p.isSeparator(line[end]) && line[end] != '"' {
+				end++
 			}
-			if strings.HasPrefix(tokens[i], "\"") && !strings.HasSuffix(tokens[i], "\"") {
-				isStringLiteral = true
+			if p.isComment(line[index:end]) {
+				return tokens, nil
 			}
-			args = append(args, tokens[i])
+			tokens = append(tokens, token{text: line[index:end], column: index + 1})
+			index = end
 		}
+	}
+	return tokens, nil
+}
 
+// scanStringLiteral reads the string literal that starts with the double quote at the given index
+// and returns its token and the index right after the closing quote.
+func (p *parserManager) scanStringLiteral(line string, start int) (string, int, error) {
+	var content strings.Builder
+	content.WriteByte('"')
+	for index := start + 1; index < len(line); index++ {
+		switch line[index] {
+		case '"':
+			content.WriteByte('"')
+			return content.String(), index + 1, nil
+		case '\\':
+			if index+1 == len(line) {
+				return "", 0, &SyntaxError{Column: start + 1, Err: ErrUnterminatedString}
+			}
+			escaped, isValid := escapeSequences[line[index+1]]
+			if !isValid {
+				return "", 0, &SyntaxError{Column: index + 1, Err: ErrInvalidEscape}
+			}
+			content.WriteByte(escaped)
+			index++
+		default:
+			content.WriteByte(line[index])
+		}
 	}
-	return Instruction{Command: command, Args: args}
+	return "", 0, &SyntaxError{Column: start + 1, Err: ErrUnterminatedString}
+}
+
+func (p *parserManager) isSeparator(character byte) bool {
+	return character == ' ' || character == '\t'
+}
+
+func (p *parserManager) isComment(word string) bool {
+	return strings.HasPrefix(word, "#") || strings.HasPrefix(word, "//")
 }
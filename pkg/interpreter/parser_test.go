@@ -1,17 +1,19 @@
 package interpreter
 
 import (
+	"errors"
 	"reflect"
 	"testing"
-
-	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
 )
 
 func TestParser(t *testing.T) {
 
 	t.Run("Testing Single Command no args", func(t *testing.T) {
-		i := NewInterpreter(&memory.MemoryManager{})
-		actual := i.parser.parse("test")
+		var parser parserManager
+		actual, err := parser.parse("test")
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
 		expected := Instruction{
 			Command: "test", Args: []string{},
 		}
@@ -23,8 +25,11 @@ func TestParser(t *testing.T) {
 	})
 
 	t.Run("Testing Multi Command multi args", func(t *testing.T) {
-		i := NewInterpreter(&memory.MemoryManager{})
-		actual := i.parser.parse("assign x 1")
+		var parser parserManager
+		actual, err := parser.parse("assign x 1")
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
 		expected := Instruction{
 			Command: "assign", Args: []string{"x", "1"},
 		}
@@ -36,8 +41,11 @@ func TestParser(t *testing.T) {
 	})
 
 	t.Run("Testing String Literal Args with no spaces", func(t *testing.T) {
-		i := NewInterpreter(&memory.MemoryManager{})
-		actual := i.parser.parse("assign x \"string_content\"")
+		var parser parserManager
+		actual, err := parser.parse("assign x \"string_content\"")
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
 		expected := Instruction{
 			Command: "assign", Args: []string{"x", "\"string_content\""},
 		}
@@ -49,8 +57,11 @@ func TestParser(t *testing.T) {
 	})
 
 	t.Run("Testing String Literal Args with spaces", func(t *testing.T) {
-		i := NewInterpreter(&memory.MemoryManager{})
-		actual := i.parser.parse("assign x \"string content test\"")
+		var parser parserManager
+		actual, err := parser.parse("assign x \"string content test\"")
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
 		expected := Instruction{
 			Command: "assign", Args: []string{"x", "\"string content test\""},
 		}
@@ -61,4 +72,86 @@ func TestParser(t *testing.T) {
 
 	})
 
+	t.Run("Testing Multiple Spaces Between Args", func(t *testing.T) {
+		var parser parserManager
+		actual, err := parser.parse("  assign  x   1")
+		if err != nil {
+			t.Fatalf("Unexpected Error %q\n", err)
+		}
+		expected := Instruction{
+			Command: "assign", Args: []string{"x", "1"},
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("Unmatched Result: expected %q, found %q\n", expected, actual)
+		}
+
+	})
+
+	t.Run("Testing Unterminated String Literal", func(t *testing.T) {
+		var parser parserManager
+		_, err := parser.parse("assign x \"string content")
+
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Column != 10 || syntaxErr.Err != ErrUnterminatedString {
+			t.Fatalf("Unmatched Result: expected %v at column 10, found %v\n", ErrUnterminatedString, err)
+		}
+
+	})
+
+	t.Run("Testing Comments", func(t *testing.T) {
+		var parser parserManager
+		tests := map[string]Instruction{
+			"# whole line":                 {Args: []string{}},
+			"// whole line":                {Args: []string{}},
+			"":                             {Args: []string{}},
+			"print x # trailing":           {Command: "print", Args: []string{"x"}},
+			"print x // trailing":          {Command: "print", Args: []string{"x"}},
+			"print \"# not // a comment\"": {Command: "print", Args: []string{"\"# not // a comment\""}},
+		}
+		for line, expected := range tests {
+			actual, err := parser.parse(line)
+			if err != nil {
+				t.Fatalf("Unexpected Error %q\n", err)
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("Unmatched Result: expected %q, found %q\n", expected, actual)
+			}
+		}
+
+	})
+
+	t.Run("Testing Lexer", func(t *testing.T) {
+		var parser parserManager
+		tests := map[string]Instruction{
+			"assign\tx\t 1":             {Command: "assign", Args: []string{"x", "1"}},
+			"print \"a  b\tc \"":        {Command: "print", Args: []string{"\"a  b\tc \""}},
+			"print \"say \\\"hi\\\"\"":  {Command: "print", Args: []string{"\"say \"hi\"\""}},
+			"print \"a\\nb\\tc\\\\\"":   {Command: "print", Args: []string{"\"a\nb\tc\\\""}},
+			"writeFile \"f\"\"data\"":   {Command: "writeFile", Args: []string{"\"f\"", "\"data\""}},
+			"print \"\" # empty string": {Command: "print", Args: []string{"\"\""}},
+		}
+		for line, expected := range tests {
+			actual, err := parser.parse(line)
+			if err != nil {
+				t.Fatalf("Unexpected Error %q\n", err)
+			}
+			if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("Unmatched Result for %q: expected %q, found %q\n", line, expected, actual)
+			}
+		}
+
+	})
+
+	t.Run("Testing Invalid Escape Sequence", func(t *testing.T) {
+		var parser parserManager
+		_, err := parser.parse("print \"a\\qb\"")
+
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Column != 9 || syntaxErr.Err != ErrInvalidEscape {
+			t.Fatalf("Unmatched Result: expected %v at column 9, found %v\n", ErrInvalidEscape, err)
+		}
+
+	})
+
 }
@@ -0,0 +1,194 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Diagnostic reports a problem found in a program at the given line and column.
+type Diagnostic struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+}
+
+// ValidationError is returned when a program is rejected because of the problems found by Validate.
+type ValidationError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Diagnostics))
+	for index, diagnostic := range e.Diagnostics {
+		messages[index] = diagnostic.String()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// validator collects the diagnostics of a program while it's checked line by line.
+type validator struct {
+	file        string
+	line        int
+	decoder     decoderManager
+	diagnostics []Diagnostic
+}
+
+// jumpTarget is a label used by a jump, it's checked once every label is declared.
+type jumpTarget struct {
+	line  int
+	label token
+}
+
+// Validate parses every line of the program without running it and returns all the problems found,
+// the file name is only used to report the diagnostics.
+func Validate(file string, code []string) []Diagnostic {
+	var parser parserManager
+	v := validator{file: file}
+	labels := map[string]bool{}
+	jumps := []jumpTarget{}
+	for index, line := range code {
+		v.line = index + 1
+		tokens, err := parser.tokenize(line)
+		var syntaxErr *SyntaxError
+		if errors.As(err, &syntaxErr) {
+			v.report(syntaxErr.Column, syntaxErr.Err.Error())
+			continue
+		}
+		if len(tokens) == 0 {
+			// blank lines and comments aren't instructions
+			continue
+		}
+
+		command, args := tokens[0], tokens[1:]
+		if command.text == "label" {
+			if len(args) != 1 {
+				v.report(command.column, "label expects 1 argument")
+			} else if labels[args[0].text] {
+				v.report(args[0].column, fmt.Sprintf("label %q is already declared", args[0].text))
+			}
+			if len(args) > 0 {
+				labels[args[0].text] = true
+			}
+			continue
+		}
+		argTexts := make([]string, len(args))
+		for index, arg := range args {
+			argTexts[index] = arg.text
+		}
+		if argIndex, isJump := labelIndex(command.text, argTexts); isJump && argIndex < len(args) {
+			jumps = append(jumps, jumpTarget{line: v.line, label: args[argIndex]})
+		}
+		v.checkInstruction(command, args)
+	}
+
+	for _, jump := range jumps {
+		if !labels[jump.label.text] {
+			v.line = jump.line
+			v.report(jump.label.column, fmt.Sprintf("undefined label %q", jump.label.text))
+		}
+	}
+	return v.diagnostics
+}
+
+func (v *validator) report(column int, message string) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{File: v.file, Line: v.line, Column: column, Message: message})
+}
+
+// checkInstruction checks the command exists and its arguments match its parameters.
+func (v *validator) checkInstruction(command token, args []token) {
+	matchedCommand, isPresent := availableCommands[command.text]
+	if _, isOperator := expressionOperators[command.text]; !isPresent && isOperator {
+		// expressions such as readFile and input only produce a value, they can't stand alone
+		v.report(command.column, fmt.Sprintf("%s is an expression, assign its result like \"assign x %s ...\"", command.text, command.text))
+		return
+	}
+	if !isPresent {
+		v.report(command.column, fmt.Sprintf("unknown command %q", command.text))
+		return
+	}
+
+	parameters := matchedCommand.parameters
+	labelIndex, isJump := jumpCommands[command.text]
+	index := 0
+	for next := 0; next < len(args); index++ {
+		if index == len(parameters) {
+			v.report(args[next].column, fmt.Sprintf("%s expects %d arguments, found more", command.text, len(parameters)))
+			return
+		}
+		arg := args[next]
+		switch {
+		case index == 0 && destinationCommands[command.text]:
+			if !v.decoder.isSymbol(arg.text) {
+				v.report(arg.column, fmt.Sprintf("expected a variable, found %s", arg.text))
+			}
+			next++
+		case index == 0 && resourceCommands[command.text]:
+			if !v.decoder.isSymbol(arg.text) && !v.decoder.isStringLiteral(arg.text) {
+				v.report(arg.column, fmt.Sprintf("expected a resource, found %s", arg.text))
+			}
+			next++
+		case isJump && index == labelIndex:
+			next++
+		default:
+			var argType parameterType
+			var isComplete bool
+			argType, next, isComplete = v.checkExpression(args, next)
+			if !isComplete {
+				return
+			}
+			v.checkType(arg, argType, parameters[index])
+		}
+	}
+	if index < len(parameters) {
+		v.report(command.column, fmt.Sprintf("%s expects %d arguments, found %d", command.text, len(parameters), index))
+	}
+}
+
+// checkExpression checks the expression starting at the given argument and returns its type
+// and the index of the argument after it, ANY is returned when the type is only known at execution.
+// It returns false if the expression is missing operands.
+func (v *validator) checkExpression(args []token, start int) (parameterType, int, bool) {
+	arg := args[start]
+	op, isOperator := expressionOperators[arg.text]
+	if !isOperator {
+		if v.decoder.isSymbol(arg.text) {
+			return ANY, start + 1, true
+		}
+		_, valueType, _ := v.decoder.getValueType(arg.text)
+		return valueType, start + 1, true
+	}
+
+	next := start + 1
+	for _, parameter := range op.parameters {
+		if next == len(args) {
+			v.report(arg.column, fmt.Sprintf("%s expects %d operands", arg.text, len(op.parameters)))
+			return ANY, next, false
+		}
+		operand := args[next]
+		var operandType parameterType
+		var isComplete bool
+		operandType, next, isComplete = v.checkExpression(args, next)
+		if !isComplete {
+			return ANY, next, false
+		}
+		v.checkType(operand, operandType, parameter)
+	}
+	return op.result, next, true
+}
+
+func (v *validator) checkType(arg token, argType parameterType, parameter parameterType) {
+	if argType == ANY || parameter == ANY || argType == parameter {
+		return
+	}
+	expected := "an integer"
+	if parameter == STRING {
+		expected = "a string"
+	}
+	v.report(arg.column, fmt.Sprintf("expected %s, found %s", expected, arg.text))
+}
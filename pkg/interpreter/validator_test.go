@@ -0,0 +1,108 @@
+package interpreter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Test Valid Program", func(t *testing.T) {
+		code := []string{
+			"# read a number and count up",
+			"assign x input",
+			"",
+			"label loop // loop start",
+			"add x x 1",
+			"lt c x add 2 3",
+			"jnz c loop",
+			"jz eq x 3 loop",
+			"semWait file",
+			"print readFile \"data\"",
+			"semSignal file",
+		}
+		if diagnostics := Validate("program", code); len(diagnostics) != 0 {
+			t.Fatalf("Expected no diagnostics, Found %v\n", diagnostics)
+		}
+	})
+
+	tests := map[string]struct {
+		code     []string
+		expected Diagnostic
+	}{
+		"unterminated string": {
+			code:     []string{"assign x 1", "print \"hello world"},
+			expected: Diagnostic{File: "program", Line: 2, Column: 7, Message: ErrUnterminatedString.Error()},
+		},
+		"after blank lines and comments": {
+			code:     []string{"# comment", "", "assign x 1 // one", "  prnt x # typo"},
+			expected: Diagnostic{File: "program", Line: 4, Column: 3, Message: "unknown command \"prnt\""},
+		},
+		"unknown command": {
+			code:     []string{"assign x 1", "  prnt x"},
+			expected: Diagnostic{File: "program", Line: 2, Column: 3, Message: "unknown command \"prnt\""},
+		},
+		"expression statement": {
+			code:     []string{"readFile \"data\""},
+			expected: Diagnostic{File: "program", Line: 1, Column: 1, Message: "readFile is an expression, assign its result like \"assign x readFile ...\""},
+		},
+		"missing argument": {
+			code:     []string{"assign x"},
+			expected: Diagnostic{File: "program", Line: 1, Column: 1, Message: "assign expects 2 arguments, found 1"},
+		},
+		"extra argument": {
+			code:     []string{"print x y"},
+			expected: Diagnostic{File: "program", Line: 1, Column: 9, Message: "print expects 1 arguments, found more"},
+		},
+		"missing operand": {
+			code:     []string{"assign x add 1"},
+			expected: Diagnostic{File: "program", Line: 1, Column: 10, Message: "add expects 2 operands"},
+		},
+		"string argument": {
+			code:     []string{"printFromTo \"1\" 3"},
+			expected: Diagnostic{File: "program", Line: 1, Column: 13, Message: "expected an integer, found \"1\""},
+		},
+		"string operand": {
+			code:     []string{"assign x mul 2 \"3\""},
+			expected: Diagnostic{File: "program", Line: 1, Column: 16, Message: "expected an integer, found \"3\""},
+		},
+		"literal destination": {
+			code:     []string{"assign 1 2"},
+			expected: Diagnostic{File: "program", Line: 1, Column: 8, Message: "expected a variable, found 1"},
+		},
+		"operator destination": {
+			code:     []string{"assign sub 5"},
+			expected: Diagnostic{File: "program", Line: 1, Column: 8, Message: "expected a variable, found sub"},
+		},
+		"operator resource": {
+			code:     []string{"semWait readFile"},
+			expected: Diagnostic{File: "program", Line: 1, Column: 9, Message: "expected a resource, found readFile"},
+		},
+		"undefined label": {
+			code:     []string{"assign x 1", "jz x end"},
+			expected: Diagnostic{File: "program", Line: 2, Column: 6, Message: "undefined label \"end\""},
+		},
+		"duplicate label": {
+			code:     []string{"label a", "label a"},
+			expected: Diagnostic{File: "program", Line: 2, Column: 7, Message: "label \"a\" is already declared"},
+		},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			diagnostics := Validate("program", test.code)
+			expected := []Diagnostic{test.expected}
+			if !reflect.DeepEqual(expected, diagnostics) {
+				t.Fatalf("Unexpected mismatch: expected %v, found %v\n", expected, diagnostics)
+			}
+		})
+	}
+
+	t.Run("Test Report Every Problem", func(t *testing.T) {
+		diagnostics := Validate("program", []string{"prnt x", "assign x 1", "", "jmp end"})
+		if len(diagnostics) != 2 {
+			t.Fatalf("Expected 2 diagnostics, Found %v\n", diagnostics)
+		}
+		if diagnostics[0].String() != "program:1:1: unknown command \"prnt\"" {
+			t.Fatalf("Unexpected diagnostic %q\n", diagnostics[0].String())
+		}
+	})
+}
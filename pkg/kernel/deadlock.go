@@ -0,0 +1,108 @@
+package kernel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+	"github.com/KhaledHegazy222/os-simulator/pkg/mutex"
+)
+
+const (
+	// NoRecovery stops the simulation and reports the deadlock.
+	NoRecovery = "none"
+	// AbortYoungest terminates the most recently created process of every deadlock.
+	AbortYoungest = "abort-youngest"
+	// AbortAll terminates every process of every deadlock.
+	AbortAll = "abort-all"
+	// PreemptResource takes every resource of the youngest process of a deadlock
+	// and rolls it back to its first instruction.
+	PreemptResource = "preempt"
+)
+
+var (
+	// ErrUnknownRecovery is returned when the kernel is configured with an unknown recovery strategy.
+	ErrUnknownRecovery = errors.New("unknown deadlock recovery strategy")
+	// ErrDeadlockVictim is the fault of a process aborted to recover from a deadlock.
+	ErrDeadlockVictim = errors.New("aborted to recover from a deadlock")
+)
+
+// DeadlockError reports the deadlocks found when no process can make progress.
+type DeadlockError struct {
+	Deadlocks []mutex.Deadlock
+}
+
+func (e *DeadlockError) Error() string {
+	message := "deadlock detected"
+	for _, deadlock := range e.Deadlocks {
+		message += fmt.Sprintf(": processes %v waiting for resources %v", deadlock.Processes, deadlock.Resources)
+	}
+	return message
+}
+
+// PendingRequestsError reports the requests the Banker's algorithm never granted when no process can make progress.
+type PendingRequestsError struct {
+	Requests []mutex.Request
+}
+
+func (e *PendingRequestsError) Error() string {
+	message := "requests not granted by the banker's algorithm"
+	for _, request := range e.Requests {
+		message += fmt.Sprintf(": process %d waiting for resource %v", request.Process, request.Resource)
+	}
+	return message
+}
+
+func isValidRecovery(recovery string) bool {
+	switch recovery {
+	case NoRecovery, AbortYoungest, AbortAll, PreemptResource:
+		return true
+	}
+	return false
+}
+
+// recoverFromDeadlocks breaks the given deadlocks with the configured strategy.
+// Returns the faults of the aborted processes.
+func (k *Kernel) recoverFromDeadlocks(deadlocks []mutex.Deadlock) ([]error, error) {
+	faults := []error{}
+	for _, deadlock := range deadlocks {
+		victims := deadlock.Processes
+		if k.recovery != AbortAll {
+			victims = []mutex.Process{youngest(deadlock.Processes)}
+		}
+
+		for _, victim := range victims {
+			process := k.processes[int(victim)]
+			if k.recovery == PreemptResource {
+				if err := k.rollback(process); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := k.terminate(process); err != nil {
+				return nil, err
+			}
+			faults = append(faults, &ProcessError{Id: process.Id, Err: ErrDeadlockVictim})
+		}
+	}
+	return faults, nil
+}
+
+// rollback takes every resource of the blocked process and restarts it from its first instruction.
+func (k *Kernel) rollback(process *memory.PCB) error {
+	if err := k.releaseResources(process); err != nil {
+		return err
+	}
+	process.ResetPC()
+	return k.scheduler.UnBlockProcess(process.Id)
+}
+
+func youngest(processes []mutex.Process) mutex.Process {
+	victim := processes[0]
+	for _, process := range processes {
+		if process > victim {
+			victim = process
+		}
+	}
+	return victim
+}
@@ -0,0 +1,316 @@
+// Package kernel provides the core of the operating system simulation that
+// owns the clock and ties the scheduler, memory, interpreter and mutex together.
+package kernel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/interpreter"
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+	"github.com/KhaledHegazy222/os-simulator/pkg/mutex"
+	"github.com/KhaledHegazy222/os-simulator/pkg/scheduler"
+	"github.com/KhaledHegazy222/os-simulator/pkg/systemcalls"
+)
+
+// Kernel represents the simulated operating system kernel.
+type Kernel struct {
+	clock       int
+	os          *systemcalls.OS
+	memory      *memory.MemoryManager
+	scheduler   *scheduler.Scheduler
+	interpreter *interpreter.Interpreter
+	mutex       *mutex.Mutex
+	recovery    string
+	processes   map[int]*memory.PCB
+	swapDir     string
+	swapped     map[int]bool
+}
+
+// Config holds the settings the kernel is created with.
+type Config struct {
+	// Quantum is the number of instructions a process executes before preemption.
+	Quantum int
+	// Policy is the name of the scheduling policy, see scheduler.NewPolicy.
+	Policy string
+	// DeadlockRecovery is the strategy used when the remaining processes are deadlocked.
+	DeadlockRecovery string
+	// Avoidance grants resource requests with the Banker's algorithm to avoid deadlocks.
+	Avoidance bool
+	// Input is where processes read input from, the standard input is used if it's nil.
+	Input io.Reader
+	// Output is where processes print to, the standard output is used if it's nil.
+	Output io.Writer
+	// Memory is the size of the RAM and the layout of every process in it, the default is used if it's zero.
+	Memory memory.Config
+	// SwapDir is the directory processes are swapped out to when the memory is full,
+	// swapping is disabled if it's empty.
+	SwapDir string
+	// References is the reference string the optimal page replacement policy evicts pages with,
+	// it's recorded from a previous run of the same programs, see Kernel.References.
+	References []memory.PageRef
+}
+
+// ProcessError reports a process that was terminated because of a fault.
+type ProcessError struct {
+	Id  int
+	Err error
+}
+
+var (
+	// ErrAllProcessesBlocked is returned when processes are alive but none of them is ready.
+	ErrAllProcessesBlocked = errors.New("all remaining processes are blocked")
+	// ErrMissingReferences is returned when the optimal page replacement is chosen without a reference string.
+	ErrMissingReferences = errors.New("optimal page replacement needs a reference string")
+)
+
+func (e *ProcessError) Error() string {
+	return fmt.Sprintf("process %d: %v", e.Id, e.Err)
+}
+
+func (e *ProcessError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultConfig returns the configuration used when no settings are given.
+func DefaultConfig() Config {
+	return Config{
+		Quantum:          scheduler.DefaultQuantum,
+		Policy:           scheduler.RoundRobinPolicy,
+		DeadlockRecovery: NoRecovery,
+		Memory:           memory.DefaultConfig(),
+	}
+}
+
+// NewKernel creates a new kernel with empty memory and no processes.
+func NewKernel(config Config) (*Kernel, error) {
+	if !isValidRecovery(config.DeadlockRecovery) {
+		return nil, ErrUnknownRecovery
+	}
+	memoryConfig := config.Memory
+	if memoryConfig == (memory.Config{}) {
+		memoryConfig = memory.DefaultConfig()
+	}
+	memoryManager, err := memory.NewMemoryManager(memoryConfig)
+	if err != nil {
+		return nil, err
+	}
+	if memoryConfig.Replacement == memory.OptimalReplacement {
+		if len(config.References) == 0 {
+			return nil, ErrMissingReferences
+		}
+		memoryManager.SetReplacementPolicy(memory.NewOptimal(config.References))
+	}
+	processMutex := mutex.NewMutex()
+	if config.Avoidance {
+		processMutex.EnableAvoidance()
+	}
+	processScheduler := scheduler.NewScheduler()
+	if err := processScheduler.SetQuantum(config.Quantum); err != nil {
+		return nil, err
+	}
+	policy, err := scheduler.NewPolicy(config.Policy)
+	if err != nil {
+		return nil, err
+	}
+	processScheduler.SetPolicy(policy)
+	input, output := config.Input, config.Output
+	if input == nil {
+		input = os.Stdin
+	}
+	if output == nil {
+		output = os.Stdout
+	}
+	processOS := systemcalls.NewOSWithIO(input, output)
+	processInterpreter := interpreter.NewInterpreter(processScheduler, &processMutex, processOS)
+	return &Kernel{
+		clock:       0,
+		os:          processOS,
+		memory:      &memoryManager,
+		scheduler:   processScheduler,
+		interpreter: &processInterpreter,
+		mutex:       &processMutex,
+		recovery:    config.DeadlockRecovery,
+		processes:   make(map[int]*memory.PCB),
+		swapDir:     config.SwapDir,
+		swapped:     make(map[int]bool),
+	}, nil
+}
+
+// Clock returns the number of ticks elapsed since the kernel started.
+func (k *Kernel) Clock() int {
+	return k.clock
+}
+
+// LoadProgram reads the program file at the given path and admits it as a new process.
+// The program is rejected with an *interpreter.ValidationError if any of its lines is invalid.
+func (k *Kernel) LoadProgram(path string) (*memory.PCB, error) {
+	unparsedCode, err := k.os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if diagnostics := interpreter.Validate(path, unparsedCode); len(diagnostics) > 0 {
+		return nil, &interpreter.ValidationError{Diagnostics: diagnostics}
+	}
+	return k.AddProcess(unparsedCode)
+}
+
+// AddProcess compiles the given code, allocates it in memory and adds its pcb to the ready queue.
+// Other processes are swapped out if the memory is full and swapping is enabled.
+func (k *Kernel) AddProcess(unparsedCode []string) (*memory.PCB, error) {
+	code, err := interpreter.Compile(unparsedCode)
+	if err != nil {
+		return nil, err
+	}
+	var pcb memory.PCB
+	err = k.allocateWithSwapping(0, func() (err error) {
+		pcb, err = k.memory.AddProcess(code)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	process := &pcb
+	if err := k.scheduler.AddToReadyQueue(process); err != nil {
+		k.memory.DeleteProcess(process.Id)
+		return nil, err
+	}
+	k.processes[process.Id] = process
+	return process, nil
+}
+
+// Fragmentation returns how the free memory was split into holes after every allocation in order.
+func (k *Kernel) Fragmentation() []memory.FragmentationStats {
+	return k.memory.FragmentationHistory()
+}
+
+// PageStats returns the page hits, faults and evictions of the process with the given id.
+func (k *Kernel) PageStats(pid int) memory.PageStats {
+	return k.memory.PageStats(pid)
+}
+
+// References returns the pages referenced by the executed instructions in order.
+func (k *Kernel) References() []memory.PageRef {
+	return k.memory.References()
+}
+
+// SetProcessIO redirects the input and output of the process with the given id.
+func (k *Kernel) SetProcessIO(pid int, reader io.Reader, writer io.Writer) {
+	k.interpreter.SetProcessIO(pid, reader, writer)
+}
+
+// HasProcesses reports whether any process is still alive.
+func (k *Kernel) HasProcesses() bool {
+	return len(k.processes) > 0
+}
+
+// Step advances the clock by one tick and executes a single instruction of the next ready process.
+// A *ProcessError is returned if the executed process was terminated because of a fault.
+func (k *Kernel) Step() error {
+	process, err := k.scheduler.GetNextReadyProcess()
+	if err != nil {
+		return err
+	}
+	if k.swapped[process.Id] {
+		if err := k.swapIn(process); err != nil {
+			return err
+		}
+	}
+	if err := k.resolvePageFaults(process); err != nil {
+		return err
+	}
+	k.clock++
+
+	err = k.interpreter.Execute(process)
+	switch {
+	case errors.Is(err, interpreter.ErrBlockedProcess):
+		return k.scheduler.BlockProcess(process.Id)
+	case errors.Is(err, memory.EndOfInstructionsErr):
+		return k.terminate(process)
+	case err != nil:
+		if terminateErr := k.terminate(process); terminateErr != nil {
+			return terminateErr
+		}
+		return &ProcessError{Id: process.Id, Err: err}
+	}
+
+	// Terminate as soon as the last instruction has been executed,
+	// a process blocked on its last instruction terminates after it is woken up
+	if process.State == memory.Blocked {
+		return nil
+	}
+	if _, err := process.GetNextInstruction(); errors.Is(err, memory.EndOfInstructionsErr) {
+		return k.terminate(process)
+	}
+	return nil
+}
+
+// Run steps the kernel until every process terminates.
+// Faulted processes don't stop the simulation, their errors are joined and returned at the end.
+// When no process is ready, the deadlocks between the blocked processes are detected and broken
+// with the configured recovery strategy, requests the Banker's algorithm never granted are reported.
+func (k *Kernel) Run() error {
+	var faults []error
+	for k.HasProcesses() {
+		err := k.Step()
+		var processErr *ProcessError
+		switch {
+		case errors.As(err, &processErr):
+			faults = append(faults, err)
+		case errors.Is(err, scheduler.ErrNoReadyProcesses):
+			deadlocks := k.mutex.DetectDeadlock()
+			if pending := k.mutex.PendingRequests(); len(deadlocks) == 0 && len(pending) > 0 {
+				faults = append(faults, &PendingRequestsError{Requests: pending})
+				return errors.Join(faults...)
+			}
+			if len(deadlocks) == 0 {
+				faults = append(faults, ErrAllProcessesBlocked)
+				return errors.Join(faults...)
+			}
+			if k.recovery == NoRecovery {
+				faults = append(faults, &DeadlockError{Deadlocks: deadlocks})
+				return errors.Join(faults...)
+			}
+			victimFaults, err := k.recoverFromDeadlocks(deadlocks)
+			if err != nil {
+				return err
+			}
+			faults = append(faults, victimFaults...)
+		case err != nil:
+			return err
+		}
+	}
+	return errors.Join(faults...)
+}
+
+func (k *Kernel) terminate(process *memory.PCB) error {
+	if err := k.scheduler.TerminateProcess(process.Id); err != nil {
+		return err
+	}
+	if err := k.releaseResources(process); err != nil {
+		return err
+	}
+	if k.swapped[process.Id] {
+		delete(k.swapped, process.Id)
+		if err := k.os.DeleteFile(k.swapPath(process.Id)); err != nil {
+			return err
+		}
+	} else if err := k.memory.DeleteProcess(process.Id); err != nil {
+		return err
+	}
+	process.State = memory.Terminated
+	delete(k.processes, process.Id)
+	return nil
+}
+
+// releaseResources hands the resources held by the process to their next waiters.
+func (k *Kernel) releaseResources(process *memory.PCB) error {
+	for _, nextOwner := range k.mutex.ReleaseAll(mutex.Process(process.Id)) {
+		if err := k.scheduler.UnBlockProcess(int(nextOwner)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,503 @@
+package kernel
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/interpreter"
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+	"github.com/KhaledHegazy222/os-simulator/pkg/scheduler"
+)
+
+func TestAddProcess(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+
+	process, err := k.AddProcess([]string{"assign x 1"})
+	if err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if k.processes[process.Id] != process {
+		t.Errorf("expected process %v to be tracked by the kernel", process.Id)
+	}
+	if !k.HasProcesses() {
+		t.Errorf("expected kernel to have processes")
+	}
+}
+
+func TestAddProcessCompilesCode(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+
+	process, err := k.AddProcess([]string{"assign x 1", "print x"})
+	if err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if instruction, _ := process.GetNextInstruction(); instruction != "0 v1:x i1:1" {
+		t.Errorf("expected %q, found %q", "0 v1:x i1:1", instruction)
+	}
+
+	if _, err := k.AddProcess([]string{"unknownCommand"}); err != interpreter.ErrInvalidCommand {
+		t.Errorf("expected %v, found %v", interpreter.ErrInvalidCommand, err)
+	}
+}
+
+func TestNewKernel(t *testing.T) {
+	if _, err := NewKernel(Config{Quantum: 1, Policy: scheduler.RoundRobinPolicy, DeadlockRecovery: "retry"}); err != ErrUnknownRecovery {
+		t.Errorf("expected %v, found %v", ErrUnknownRecovery, err)
+	}
+	if _, err := NewKernel(Config{Quantum: 0, Policy: scheduler.RoundRobinPolicy, DeadlockRecovery: NoRecovery}); err != scheduler.ErrInvalidQuantum {
+		t.Errorf("expected %v, found %v", scheduler.ErrInvalidQuantum, err)
+	}
+	if _, err := NewKernel(Config{Quantum: 1, Policy: "lottery", DeadlockRecovery: NoRecovery}); err != scheduler.ErrUnknownPolicy {
+		t.Errorf("expected %v, found %v", scheduler.ErrUnknownPolicy, err)
+	}
+	if _, err := NewKernel(Config{Quantum: 2, Policy: scheduler.RoundRobinPolicy, DeadlockRecovery: NoRecovery}); err != nil {
+		t.Errorf("expected nil, found %v", err)
+	}
+}
+
+func TestStep(t *testing.T) {
+	t.Run("advance clock and terminate after last instruction", func(t *testing.T) {
+		k, _ := NewKernel(DefaultConfig())
+		process, _ := k.AddProcess([]string{"assign x 1", "assign y 2"})
+
+		if err := k.Step(); err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+		if k.Clock() != 1 {
+			t.Errorf("expected 1, found %v", k.Clock())
+		}
+		if !k.HasProcesses() {
+			t.Errorf("expected process %v to be alive", process.Id)
+		}
+
+		if err := k.Step(); err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+		if k.HasProcesses() {
+			t.Errorf("expected process %v to be terminated", process.Id)
+		}
+	})
+
+	t.Run("terminate faulted process", func(t *testing.T) {
+		k, _ := NewKernel(DefaultConfig())
+		process, _ := k.AddProcess([]string{"div x 1 0", "assign x 1"})
+
+		err := k.Step()
+		var processErr *ProcessError
+		if !errors.As(err, &processErr) || processErr.Id != process.Id {
+			t.Fatalf("expected process error for %v, found %v", process.Id, err)
+		}
+		if !errors.Is(err, interpreter.ErrRunTimeError) {
+			t.Errorf("expected %v, found %v", interpreter.ErrRunTimeError, err)
+		}
+		if k.HasProcesses() {
+			t.Errorf("expected process %v to be terminated", process.Id)
+		}
+	})
+}
+
+func TestRun(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+	k.AddProcess([]string{"assign x 1", "assign y 2", "assign z 3"})
+	k.AddProcess([]string{"assign x 1"})
+	k.AddProcess([]string{"div x 1 0"})
+
+	err := k.Run()
+	if !errors.Is(err, interpreter.ErrRunTimeError) {
+		t.Errorf("expected %v, found %v", interpreter.ErrRunTimeError, err)
+	}
+	if k.HasProcesses() {
+		t.Errorf("expected all processes to be terminated")
+	}
+	if k.Clock() != 5 {
+		t.Errorf("expected 5, found %v", k.Clock())
+	}
+}
+
+func TestRunWithQuantum(t *testing.T) {
+	k, _ := NewKernel(Config{Quantum: 2, Policy: scheduler.RoundRobinPolicy, DeadlockRecovery: NoRecovery})
+	first, _ := k.AddProcess([]string{"assign x 1", "assign y 2", "assign z 3"})
+	second, _ := k.AddProcess([]string{"assign x 1", "assign y 2"})
+
+	// first process keeps the cpu for two instructions
+	k.Step()
+	k.Step()
+	if first.PC != first.Start+memory.PCBSize+2 {
+		t.Errorf("expected first process to execute two instructions, pc is %v", first.PC)
+	}
+	if second.PC != second.Start+6 {
+		t.Errorf("expected second process not to execute, pc is %v", second.PC)
+	}
+
+	if err := k.Run(); err != nil {
+		t.Errorf("expected nil, found %v", err)
+	}
+	if k.Clock() != 5 {
+		t.Errorf("expected 5, found %v", k.Clock())
+	}
+}
+
+func TestRunWithPolicy(t *testing.T) {
+	k, _ := NewKernel(Config{Quantum: 1, Policy: scheduler.ShortestJobFirstPolicy, DeadlockRecovery: NoRecovery})
+	long, _ := k.AddProcess([]string{"assign x 1", "assign y 2", "assign z 3"})
+	short, _ := k.AddProcess([]string{"assign x 1"})
+
+	k.Step()
+	if short.State != memory.Terminated {
+		t.Errorf("expected shortest process to run first and terminate, found %v", short.State)
+	}
+	if long.PC != long.Start+memory.PCBSize {
+		t.Errorf("expected longest process not to execute, pc is %v", long.PC)
+	}
+}
+
+func TestRunMutualExclusion(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+	first, _ := k.AddProcess([]string{"semWait file", "assign x 1", "assign y 2", "semSignal file"})
+	second, _ := k.AddProcess([]string{"semWait file", "assign x 3", "semSignal file"})
+
+	k.Step()
+	k.Step()
+	if second.State != memory.Blocked {
+		t.Fatalf("expected %v, found %v", memory.Blocked, second.State)
+	}
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if first.State != memory.Terminated || second.State != memory.Terminated {
+		t.Errorf("expected both processes to terminate")
+	}
+	if k.Clock() != 7 {
+		t.Errorf("expected 7, found %v", k.Clock())
+	}
+}
+
+func TestRunAllProcessesBlocked(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+	k.AddProcess([]string{"semInit items 0", "semWait items"})
+
+	if err := k.Run(); !errors.Is(err, ErrAllProcessesBlocked) {
+		t.Errorf("expected %v, found %v", ErrAllProcessesBlocked, err)
+	}
+}
+
+var deadlockedPrograms = [][]string{
+	{"semWait first", "semWait second", "semSignal second", "semSignal first"},
+	{"semWait second", "semWait first", "semSignal first", "semSignal second"},
+}
+
+func TestRunDeadlock(t *testing.T) {
+	t.Run("report deadlock without recovery", func(t *testing.T) {
+		k, _ := NewKernel(DefaultConfig())
+		k.AddProcess(deadlockedPrograms[0])
+		k.AddProcess(deadlockedPrograms[1])
+
+		err := k.Run()
+		var deadlockErr *DeadlockError
+		if !errors.As(err, &deadlockErr) {
+			t.Fatalf("expected deadlock error, found %v", err)
+		}
+		if len(deadlockErr.Deadlocks) != 1 || len(deadlockErr.Deadlocks[0].Processes) != 2 {
+			t.Errorf("expected one deadlock between two processes, found %v", deadlockErr.Deadlocks)
+		}
+	})
+
+	t.Run("abort youngest process", func(t *testing.T) {
+		config := DefaultConfig()
+		config.DeadlockRecovery = AbortYoungest
+		k, _ := NewKernel(config)
+		first, _ := k.AddProcess(deadlockedPrograms[0])
+		second, _ := k.AddProcess(deadlockedPrograms[1])
+
+		err := k.Run()
+		var processErr *ProcessError
+		if !errors.As(err, &processErr) || processErr.Id != second.Id || !errors.Is(err, ErrDeadlockVictim) {
+			t.Fatalf("expected process %v to be aborted, found %v", second.Id, err)
+		}
+		if first.State != memory.Terminated {
+			t.Errorf("expected process %v to finish, found %v", first.Id, first.State)
+		}
+	})
+
+	t.Run("abort all processes", func(t *testing.T) {
+		config := DefaultConfig()
+		config.DeadlockRecovery = AbortAll
+		k, _ := NewKernel(config)
+		first, _ := k.AddProcess(deadlockedPrograms[0])
+		k.AddProcess(deadlockedPrograms[1])
+
+		err := k.Run()
+		if !errors.Is(err, ErrDeadlockVictim) {
+			t.Fatalf("expected %v, found %v", ErrDeadlockVictim, err)
+		}
+		// the first process was aborted while blocked on its second instruction
+		if first.PC != first.Start+memory.PCBSize+2 {
+			t.Errorf("expected process %v to be aborted while blocked, pc is %v", first.Id, first.PC)
+		}
+	})
+
+	t.Run("preempt resources of youngest process", func(t *testing.T) {
+		config := DefaultConfig()
+		config.DeadlockRecovery = PreemptResource
+		k, _ := NewKernel(config)
+		k.AddProcess(deadlockedPrograms[0])
+		k.AddProcess(deadlockedPrograms[1])
+
+		if err := k.Run(); err != nil {
+			t.Fatalf("expected both processes to finish, found %v", err)
+		}
+	})
+}
+
+func TestRunDeadlockAvoidance(t *testing.T) {
+	config := DefaultConfig()
+	config.Avoidance = true
+	k, _ := NewKernel(config)
+	first, _ := k.AddProcess(append([]string{"claim first 1", "claim second 1"}, deadlockedPrograms[0]...))
+	second, _ := k.AddProcess(append([]string{"claim first 1", "claim second 1"}, deadlockedPrograms[1]...))
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected both processes to finish, found %v", err)
+	}
+	if first.State != memory.Terminated || second.State != memory.Terminated {
+		t.Errorf("expected both processes to terminate")
+	}
+}
+
+func TestRunAvoidanceWithoutClaims(t *testing.T) {
+	config := DefaultConfig()
+	config.Avoidance = true
+	k, _ := NewKernel(config)
+	first, _ := k.AddProcess(deadlockedPrograms[0])
+	second, _ := k.AddProcess(deadlockedPrograms[1])
+
+	err := k.Run()
+	if !errors.Is(err, interpreter.ErrRunTimeError) || errors.Is(err, ErrAllProcessesBlocked) {
+		t.Errorf("expected only %v, found %v", interpreter.ErrRunTimeError, err)
+	}
+	if first.State != memory.Terminated || second.State != memory.Terminated {
+		t.Errorf("expected both processes to terminate")
+	}
+}
+
+func TestTerminateReleasesResources(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+	k.AddProcess([]string{"semWait file", "div x 1 0"})
+	second, _ := k.AddProcess([]string{"semWait file", "assign x 1"})
+
+	err := k.Run()
+	if !errors.Is(err, interpreter.ErrRunTimeError) || errors.Is(err, ErrAllProcessesBlocked) {
+		t.Errorf("expected only %v, found %v", interpreter.ErrRunTimeError, err)
+	}
+	if second.State != memory.Terminated {
+		t.Errorf("expected %v, found %v", memory.Terminated, second.State)
+	}
+}
+
+func TestRunCountingSemaphore(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+	consumer, _ := k.AddProcess([]string{"semInit items 0", "semWait items", "semWait items", "assign x 1"})
+	producer, _ := k.AddProcess([]string{"assign x 1", "semSignal items", "assign y 2", "semSignal items"})
+
+	// consumer waits for an item that is not produced yet
+	k.Step()
+	k.Step()
+	k.Step()
+	if consumer.State != memory.Blocked {
+		t.Fatalf("expected %v, found %v", memory.Blocked, consumer.State)
+	}
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if consumer.State != memory.Terminated || producer.State != memory.Terminated {
+		t.Errorf("expected both processes to terminate")
+	}
+}
+
+func TestRunSharedSemaphoreDeclaration(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+	first, _ := k.AddProcess([]string{"semInit slots 1", "semWait slots", "assign x 1", "semSignal slots"})
+	second, _ := k.AddProcess([]string{"semInit slots 1", "semWait slots", "assign x 2", "semSignal slots"})
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if first.State != memory.Terminated || second.State != memory.Terminated {
+		t.Errorf("expected both processes to terminate")
+	}
+}
+
+func TestRunRedirectedIO(t *testing.T) {
+	t.Run("kernel input and output", func(t *testing.T) {
+		var output bytes.Buffer
+		config := DefaultConfig()
+		config.Input = strings.NewReader("7\n")
+		config.Output = &output
+		k, _ := NewKernel(config)
+		k.AddProcess([]string{"assign x input", "print x"})
+
+		if err := k.Run(); err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+		if output.String() != "7\n" {
+			t.Errorf("expected %q, found %q", "7\n", output.String())
+		}
+	})
+
+	t.Run("per process input and output", func(t *testing.T) {
+		var kernelOutput, firstOutput, secondOutput bytes.Buffer
+		config := DefaultConfig()
+		config.Output = &kernelOutput
+		k, _ := NewKernel(config)
+		first, _ := k.AddProcess([]string{"assign x input", "print x"})
+		second, _ := k.AddProcess([]string{"assign x input", "print x"})
+		k.SetProcessIO(first.Id, strings.NewReader("1\n"), &firstOutput)
+		k.SetProcessIO(second.Id, strings.NewReader("2\n"), &secondOutput)
+
+		if err := k.Run(); err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+		if firstOutput.String() != "1\n" || secondOutput.String() != "2\n" {
+			t.Errorf("expected outputs %q and %q, found %q and %q", "1\n", "2\n", firstOutput.String(), secondOutput.String())
+		}
+		if kernelOutput.Len() != 0 {
+			t.Errorf("expected nothing printed to the kernel output, found %q", kernelOutput.String())
+		}
+	})
+}
+
+func TestRunReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data")
+	os.WriteFile(path, []byte("file contents"), 0666)
+
+	var output bytes.Buffer
+	config := DefaultConfig()
+	config.Input = strings.NewReader(path + "\n")
+	config.Output = &output
+	k, _ := NewKernel(config)
+	k.AddProcess([]string{"assign a input", "assign b readFile a", "print b"})
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if output.String() != "file contents\n" {
+		t.Errorf("expected %q, found %q", "file contents\n", output.String())
+	}
+}
+
+func TestRunLoop(t *testing.T) {
+	var output bytes.Buffer
+	config := DefaultConfig()
+	config.Output = &output
+	k, _ := NewKernel(config)
+	process, err := k.AddProcess([]string{
+		"assign i 1",
+		"label loop",
+		"print i",
+		"add i i 1",
+		"lt c i 4",
+		"jnz c loop",
+	})
+	if err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if process.CodeSize != 5 {
+		t.Errorf("expected code size 5, found %v", process.CodeSize)
+	}
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if output.String() != "1\n2\n3\n" {
+		t.Errorf("expected %q, found %q", "1\n2\n3\n", output.String())
+	}
+}
+
+func TestAddProcessUndefinedLabel(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+	if _, err := k.AddProcess([]string{"jmp end"}); err != interpreter.ErrUndefinedLabel {
+		t.Errorf("expected %v, found %v", interpreter.ErrUndefinedLabel, err)
+	}
+	if k.HasProcesses() {
+		t.Errorf("expected no processes")
+	}
+}
+
+func TestLoadInvalidProgram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "program")
+	os.WriteFile(path, []byte("assign x 1\nprnt x"), 0666)
+
+	k, _ := NewKernel(DefaultConfig())
+	_, err := k.LoadProgram(path)
+	var validationErr *interpreter.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, found %v", err)
+	}
+	if len(validationErr.Diagnostics) != 1 || validationErr.Diagnostics[0].Line != 2 {
+		t.Errorf("expected one diagnostic on line 2, found %v", validationErr.Diagnostics)
+	}
+	if k.HasProcesses() {
+		t.Errorf("expected invalid program not to be admitted")
+	}
+}
+
+func TestLoadProgramWithComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "program")
+	os.WriteFile(path, []byte("# sets x\nassign x 1\n\n// prints x\nprint x # done\n"), 0666)
+
+	var output bytes.Buffer
+	config := DefaultConfig()
+	config.Output = &output
+	k, _ := NewKernel(config)
+	process, err := k.LoadProgram(path)
+	if err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if process.CodeSize != 2 {
+		t.Errorf("expected code size 2, found %v", process.CodeSize)
+	}
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	if output.String() != "1\n" {
+		t.Errorf("expected %q, found %q", "1\n", output.String())
+	}
+}
+
+func TestRunPrintsStringLiteralsExactly(t *testing.T) {
+	var output bytes.Buffer
+	config := DefaultConfig()
+	config.Output = &output
+	k, _ := NewKernel(config)
+	k.AddProcess([]string{"assign x \"a  b\\t\\\"c\\\"\"", "print x", "print \"line\\nbreak\""})
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	expected := "a  b\t\"c\"\nline\nbreak\n"
+	if output.String() != expected {
+		t.Errorf("expected %q, found %q", expected, output.String())
+	}
+}
+
+func TestNewKernelMemoryConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.Memory = memory.Config{Words: 40, PCBSize: 2, VariablesSize: 3}
+	if _, err := NewKernel(config); err != memory.InvalidConfigErr {
+		t.Errorf("expected %v, found %v", memory.InvalidConfigErr, err)
+	}
+
+	config.Memory = memory.Config{Words: 400, PCBSize: memory.PCBSize, VariablesSize: 3}
+	k, _ := NewKernel(config)
+	for i := 0; i < 10; i++ {
+		if _, err := k.AddProcess([]string{"assign x 1", "print x"}); err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package kernel
+
+import (
+	"errors"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/interpreter"
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+// resolvePageFaults references the pages the next instruction of the process accesses before it's executed,
+// the page of the instruction and the data pages of the variables it names. The pages that aren't in a frame
+// are loaded from the backing store so the instruction never faults in the middle of its execution.
+// Processes that aren't paged never fault.
+func (k *Kernel) resolvePageFaults(process *memory.PCB) error {
+	k.memory.SetInstructionVariables(process, interpreter.Variables)
+	k.memory.ReferencePages(process)
+	for {
+		err := process.CheckPages()
+		var pageFault *memory.PageFaultError
+		if !errors.As(err, &pageFault) {
+			return err
+		}
+		if err := k.memory.LoadPage(process, pageFault.Page); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package kernel
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+func TestRunWithPaging(t *testing.T) {
+	var output bytes.Buffer
+	config := DefaultConfig()
+	config.Output = &output
+	config.Memory = memory.Config{Words: 40, PCBSize: memory.PCBSize, VariablesSize: 3, PageSize: 4}
+	k, _ := NewKernel(config)
+
+	// every process takes 49 words, more than the whole memory
+	expected := []string{}
+	for id := 1; id <= 2; id++ {
+		code := []string{}
+		for line := 0; line < 20; line++ {
+			code = append(code, fmt.Sprintf("assign x %d", id*100+line), "print x")
+			expected = append(expected, fmt.Sprint(id*100+line))
+		}
+		if _, err := k.AddProcess(code); err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+	}
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	// with a quantum of one instruction the processes print in turns
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %v lines, found %v", len(expected), output.String())
+	}
+	for id := 1; id <= 2; id++ {
+		next := 0
+		for _, line := range lines {
+			if line == fmt.Sprint(id*100+next) {
+				next++
+			}
+		}
+		if next != 20 {
+			t.Errorf("process %v: expected 20 values in order, found %v", id, next)
+		}
+	}
+}
+
+func TestPagingReferencesAccessedPages(t *testing.T) {
+	config := DefaultConfig()
+	config.Output = &bytes.Buffer{}
+	config.Memory = memory.Config{Words: 40, PCBSize: memory.PCBSize, VariablesSize: 3, PageSize: 4}
+	k, _ := NewKernel(config)
+	k.AddProcess([]string{"assign x 1", "print \"x\"", "print x"})
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	// the instructions are on pages 1, 1 and 2 and x is on page 2, the print of a literal only references its own page
+	expected := []memory.PageRef{{Id: 1, Page: 1}, {Id: 1, Page: 2}, {Id: 1, Page: 1}, {Id: 1, Page: 2}}
+	if !reflect.DeepEqual(expected, k.References()) {
+		t.Errorf("expected %v, found %v", expected, k.References())
+	}
+	if found := k.PageStats(1); found != (memory.PageStats{Hits: 2, Faults: 2}) {
+		t.Errorf("expected 2 hits and 2 faults, found %+v", found)
+	}
+}
+
+func TestRunWithOptimalReplacement(t *testing.T) {
+	code := []string{"assign x 1", "assign y 2", "add x x y", "print x", "assign y 3", "print y", "add y x y", "print y"}
+	run := func(replacement string, references []memory.PageRef) *Kernel {
+		t.Helper()
+		config := DefaultConfig()
+		config.Output = &bytes.Buffer{}
+		config.Memory = memory.Config{Words: 12, PCBSize: memory.PCBSize, VariablesSize: 3, PageSize: 4, Replacement: replacement}
+		config.References = references
+		k, _ := NewKernel(config)
+		k.AddProcess(code)
+		k.AddProcess(code)
+		if err := k.Run(); err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+		return k
+	}
+	faults := func(k *Kernel) int {
+		return k.PageStats(1).Faults + k.PageStats(2).Faults
+	}
+
+	fifo := run(memory.FIFOReplacement, nil)
+	optimal := run(memory.OptimalReplacement, fifo.References())
+	if !reflect.DeepEqual(fifo.References(), optimal.References()) {
+		t.Fatalf("expected the same reference string, found %v and %v", fifo.References(), optimal.References())
+	}
+	if faults(optimal) > faults(fifo) {
+		t.Errorf("expected at most %v faults, found %v", faults(fifo), faults(optimal))
+	}
+	for _, replacement := range []string{memory.LRUReplacement, memory.ClockReplacement} {
+		if found := faults(run(replacement, nil)); found < faults(optimal) {
+			t.Errorf("%v: expected at least %v faults, found %v", replacement, faults(optimal), found)
+		}
+	}
+}
+
+func TestOptimalReplacementWithoutReferences(t *testing.T) {
+	for _, references := range [][]memory.PageRef{nil, {}} {
+		config := DefaultConfig()
+		config.Memory = memory.Config{Words: 12, PCBSize: memory.PCBSize, VariablesSize: 3, PageSize: 4, Replacement: memory.OptimalReplacement}
+		config.References = references
+		if _, err := NewKernel(config); err != ErrMissingReferences {
+			t.Errorf("expected %v, found %v", ErrMissingReferences, err)
+		}
+	}
+}
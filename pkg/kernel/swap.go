@@ -0,0 +1,97 @@
+package kernel
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+// swapPath returns the file the words of the process are written to while it's swapped out.
+func (k *Kernel) swapPath(pid int) string {
+	return filepath.Join(k.swapDir, fmt.Sprintf("process-%d.swap", pid))
+}
+
+// allocateWithSwapping runs the allocation, swapping processes out to disk while the memory is full.
+// The process with the excluded id is never chosen as a victim.
+func (k *Kernel) allocateWithSwapping(excluded int, allocate func() error) error {
+	for {
+		err := allocate()
+		if !errors.Is(err, memory.NotEnoughSpaceErr) || k.swapDir == "" {
+			return err
+		}
+		victim := k.swapVictim(excluded)
+		if victim == nil {
+			return err
+		}
+		if err := k.swapOut(victim); err != nil {
+			return err
+		}
+	}
+}
+
+// swapVictim returns the process in memory that is swapped out first, blocked processes go
+// before ready ones and older processes before younger ones. It returns nil if no process is in memory.
+func (k *Kernel) swapVictim(excluded int) *memory.PCB {
+	ids := make([]int, 0, len(k.processes))
+	for id := range k.processes {
+		if id != excluded && !k.swapped[id] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if k.processes[id].State == memory.Blocked {
+			return k.processes[id]
+		}
+	}
+	return k.processes[ids[0]]
+}
+
+// swapOut writes the words of the process to its swap file, one quoted word per line, and frees its memory.
+func (k *Kernel) swapOut(process *memory.PCB) error {
+	words, err := k.memory.SwapOut(process)
+	if err != nil {
+		return err
+	}
+	lines := make([]string, len(words))
+	for index, word := range words {
+		lines[index] = strconv.Quote(word)
+	}
+	if err := k.os.WriteToFile(k.swapPath(process.Id), strings.Join(lines, "\n")); err != nil {
+		// the words were just freed so there's room to put them back
+		if swapInErr := k.memory.SwapIn(process, words); swapInErr != nil {
+			return errors.Join(err, swapInErr)
+		}
+		return err
+	}
+	k.swapped[process.Id] = true
+	return nil
+}
+
+// swapIn reads the words of the process back from its swap file, swapping other processes out if needed.
+func (k *Kernel) swapIn(process *memory.PCB) error {
+	path := k.swapPath(process.Id)
+	lines, err := k.os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	words := make([]string, len(lines))
+	for index, line := range lines {
+		if words[index], err = strconv.Unquote(line); err != nil {
+			return memory.InvalidSwapErr
+		}
+	}
+	if err := k.allocateWithSwapping(process.Id, func() error { return k.memory.SwapIn(process, words) }); err != nil {
+		return err
+	}
+	delete(k.swapped, process.Id)
+	return k.os.DeleteFile(path)
+}
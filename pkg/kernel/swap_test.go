@@ -0,0 +1,89 @@
+package kernel
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+// swapProgram returns a program that takes 14 of the 40 words, so only two of them fit in memory at once
+func swapProgram(name string) []string {
+	return []string{
+		"assign x " + name,
+		"print x",
+		"assign x \"" + name + "\\ndone\"",
+		"print x",
+		"print \"" + name + "\"",
+	}
+}
+
+func TestRunWithSwapping(t *testing.T) {
+	var output bytes.Buffer
+	config := DefaultConfig()
+	config.Output = &output
+	config.SwapDir = t.TempDir()
+	k, _ := NewKernel(config)
+
+	for _, name := range []string{"1", "2", "3"} {
+		if _, err := k.AddProcess(swapProgram(name)); err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+	}
+	if !k.swapped[1] {
+		t.Fatalf("expected the first process to be swapped out")
+	}
+	if _, err := os.Stat(k.swapPath(1)); err != nil {
+		t.Fatalf("expected swap file, found %v", err)
+	}
+
+	if err := k.Run(); err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	sort.Strings(lines)
+	expected := []string{"1", "1", "1", "2", "2", "2", "3", "3", "3", "done", "done", "done"}
+	if strings.Join(lines, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, found %v", expected, lines)
+	}
+	if entries, _ := os.ReadDir(config.SwapDir); len(entries) != 0 {
+		t.Errorf("expected the swap files to be deleted, found %v", entries)
+	}
+}
+
+func TestAddProcessWithoutSwapping(t *testing.T) {
+	k, _ := NewKernel(DefaultConfig())
+	k.AddProcess(swapProgram("1"))
+	k.AddProcess(swapProgram("2"))
+
+	if _, err := k.AddProcess(swapProgram("3")); !errors.Is(err, memory.NotEnoughSpaceErr) {
+		t.Errorf("expected %v, found %v", memory.NotEnoughSpaceErr, err)
+	}
+}
+
+func TestSwapVictim(t *testing.T) {
+	config := DefaultConfig()
+	config.SwapDir = t.TempDir()
+	k, _ := NewKernel(config)
+	k.AddProcess(swapProgram("1"))
+	second, _ := k.AddProcess(swapProgram("2"))
+
+	if victim := k.swapVictim(0); victim.Id != 1 {
+		t.Errorf("expected 1, found %v", victim.Id)
+	}
+	if victim := k.swapVictim(1); victim.Id != 2 {
+		t.Errorf("expected 2, found %v", victim.Id)
+	}
+	k.scheduler.BlockProcess(second.Id)
+	if victim := k.swapVictim(0); victim.Id != 2 {
+		t.Errorf("expected the blocked process 2, found %v", victim.Id)
+	}
+	k.swapped[2] = true
+	if victim := k.swapVictim(1); victim != nil {
+		t.Errorf("expected nil, found %v", victim.Id)
+	}
+}
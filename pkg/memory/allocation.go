@@ -0,0 +1,129 @@
+package memory
+
+import "errors"
+
+// Hole is a run of free words in the RAM.
+type Hole struct {
+	Start int
+	Size  int
+}
+
+// AllocationStrategy decides which hole a new process is placed in.
+type AllocationStrategy interface {
+	// Select returns the index of the hole the process of the given size is placed at the start of,
+	// or -1 if no hole fits it.
+	Select(holes []Hole, size int) int
+}
+
+const (
+	FirstFitStrategy = "first-fit"
+	BestFitStrategy  = "best-fit"
+	WorstFitStrategy = "worst-fit"
+	NextFitStrategy  = "next-fit"
+)
+
+var UnknownStrategyErr = errors.New("unknown allocation strategy")
+
+// NewAllocationStrategy creates the allocation strategy with the given name.
+func NewAllocationStrategy(name string) (AllocationStrategy, error) {
+	switch name {
+	case FirstFitStrategy:
+		return &FirstFit{}, nil
+	case BestFitStrategy:
+		return &BestFit{}, nil
+	case WorstFitStrategy:
+		return &WorstFit{}, nil
+	case NextFitStrategy:
+		return &NextFit{}, nil
+	}
+	return nil, UnknownStrategyErr
+}
+
+// fits reports whether the process fits in the hole.
+func fits(hole Hole, size int) bool {
+	return hole.Size >= size
+}
+
+// FirstFit places the process in the first hole that fits it.
+type FirstFit struct{}
+
+func (s *FirstFit) Select(holes []Hole, size int) int {
+	for index, hole := range holes {
+		if fits(hole, size) {
+			return index
+		}
+	}
+	return -1
+}
+
+// BestFit places the process in the smallest hole that fits it.
+type BestFit struct{}
+
+func (s *BestFit) Select(holes []Hole, size int) int {
+	selected := -1
+	for index, hole := range holes {
+		if fits(hole, size) && (selected == -1 || hole.Size < holes[selected].Size) {
+			selected = index
+		}
+	}
+	return selected
+}
+
+// WorstFit places the process in the largest hole.
+type WorstFit struct{}
+
+func (s *WorstFit) Select(holes []Hole, size int) int {
+	selected := -1
+	for index, hole := range holes {
+		if fits(hole, size) && (selected == -1 || hole.Size > holes[selected].Size) {
+			selected = index
+		}
+	}
+	return selected
+}
+
+// NextFit places the process in the first hole that fits it starting from where the last process was placed,
+// wrapping around to the start of the RAM.
+type NextFit struct {
+	next int
+}
+
+func (s *NextFit) Select(holes []Hole, size int) int {
+	first := len(holes)
+	for index, hole := range holes {
+		if hole.Start+hole.Size > s.next {
+			first = index
+			break
+		}
+	}
+	for step := 0; step < len(holes); step++ {
+		index := (first + step) % len(holes)
+		if fits(holes[index], size) {
+			s.next = holes[index].Start + size
+			return index
+		}
+	}
+	return -1
+}
+
+// FragmentationStats describes how the free words of the RAM are split into holes.
+type FragmentationStats struct {
+	LargestHole int
+	TotalFree   int
+	// ExternalFragmentation is the part of the free words outside the largest hole, from 0 to 1
+	ExternalFragmentation float64
+}
+
+func fragmentationOf(holes []Hole) FragmentationStats {
+	var stats FragmentationStats
+	for _, hole := range holes {
+		stats.TotalFree += hole.Size
+		if hole.Size > stats.LargestHole {
+			stats.LargestHole = hole.Size
+		}
+	}
+	if stats.TotalFree > 0 {
+		stats.ExternalFragmentation = 1 - float64(stats.LargestHole)/float64(stats.TotalFree)
+	}
+	return stats
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"testing"
+)
+
+var holes = []Hole{
+	{Start: 1, Size: 5},
+	{Start: 10, Size: 12},
+	{Start: 25, Size: 8},
+	{Start: 35, Size: 20},
+}
+
+func TestNewAllocationStrategy(t *testing.T) {
+	for _, name := range []string{FirstFitStrategy, BestFitStrategy, WorstFitStrategy, NextFitStrategy} {
+		if _, err := NewAllocationStrategy(name); err != nil {
+			t.Errorf("%v: expected nil, found %v", name, err)
+		}
+	}
+	if _, err := NewAllocationStrategy("buddy"); err != UnknownStrategyErr {
+		t.Errorf("expected %v, found %v", UnknownStrategyErr, err)
+	}
+}
+
+func TestSelectHole(t *testing.T) {
+	tests := map[string]struct {
+		strategy AllocationStrategy
+		size     int
+		expected int
+	}{
+		"first fit":              {strategy: &FirstFit{}, size: 6, expected: 1},
+		"best fit":               {strategy: &BestFit{}, size: 6, expected: 2},
+		"worst fit":              {strategy: &WorstFit{}, size: 6, expected: 3},
+		"next fit":               {strategy: &NextFit{}, size: 6, expected: 1},
+		"hole of the same size":  {strategy: &BestFit{}, size: 8, expected: 2},
+		"largest hole":           {strategy: &FirstFit{}, size: 20, expected: 3},
+		"first fit without hole": {strategy: &FirstFit{}, size: 21, expected: -1},
+		"best fit without hole":  {strategy: &BestFit{}, size: 21, expected: -1},
+		"worst fit without hole": {strategy: &WorstFit{}, size: 21, expected: -1},
+		"next fit without hole":  {strategy: &NextFit{}, size: 21, expected: -1},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			if found := test.strategy.Select(holes, test.size); found != test.expected {
+				t.Errorf("expected %v, found %v", test.expected, found)
+			}
+		})
+	}
+}
+
+func TestNextFitContinuesFromLastAllocation(t *testing.T) {
+	strategy := &NextFit{}
+	freeHoles := append([]Hole{}, holes...)
+	expected := []int{1, 2, 3, 1}
+	sizes := []int{6, 7, 15, 6}
+	for index, size := range sizes {
+		found := strategy.Select(freeHoles, size)
+		if found != expected[index] {
+			t.Fatalf("allocation %v: expected %v, found %v", index, expected[index], found)
+		}
+		freeHoles[found] = Hole{Start: freeHoles[found].Start + size, Size: freeHoles[found].Size - size}
+	}
+}
+
+func TestFragmentationOf(t *testing.T) {
+	found := fragmentationOf(holes)
+	if found.LargestHole != 20 {
+		t.Errorf("expected 20, found %v", found.LargestHole)
+	}
+	if found.TotalFree != 45 {
+		t.Errorf("expected 45, found %v", found.TotalFree)
+	}
+	if expected := 1 - 20.0/45.0; found.ExternalFragmentation != expected {
+		t.Errorf("expected %v, found %v", expected, found.ExternalFragmentation)
+	}
+
+	if empty := fragmentationOf([]Hole{}); empty != (FragmentationStats{}) {
+		t.Errorf("expected %v, found %v", FragmentationStats{}, empty)
+	}
+}
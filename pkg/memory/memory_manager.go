@@ -2,14 +2,15 @@ package memory
 
 import (
 	"errors"
+	"fmt"
 )
 
 const (
-	variablesSize      = 3
+	// PCBSize is the number of words the fields of a pcb take, the pcb layout can only reserve more
 	PCBSize            = 6
-	memorySize         = 40 + 3
+	variablesSize      = 3
+	memoryWords        = 40
 	memoryStartAddress = 1
-	memoryEndAddress   = 40
 )
 
 var (
@@ -17,14 +18,60 @@ var (
 	NotEnoughSpaceErr      = errors.New("not enough space in the memory")
 	ProcessIdNotFoundErr   = errors.New("process id is not found")
 	InternalMemoryErrorErr = errors.New("internal memory error")
+	InvalidConfigErr       = errors.New("invalid memory configuration")
+	InvalidSwapErr         = errors.New("swapped words don't belong to the process")
 )
 
+// Config describes the size of the simulated RAM and how every process is laid out in it.
+type Config struct {
+	// Words is the number of words the RAM holds
+	Words int
+	// PCBSize is the number of words reserved for the pcb of every process, at least PCBSize
+	PCBSize int
+	// VariablesSize is the number of data words every process gets for its variables
+	VariablesSize int
+	// Strategy is the name of the allocation strategy, first-fit is used if it's empty
+	Strategy string
+	// PageSize is the number of words in a page and a frame, processes are paged if it's set
+	// instead of allocated in contiguous blocks
+	PageSize int
+	// Replacement is the name of the page replacement policy, fifo is used if it's empty
+	Replacement string
+}
+
+// DefaultConfig returns the 40 words machine with 6 pcb words and 3 variables per process
+func DefaultConfig() Config {
+	return Config{
+		Words:         memoryWords,
+		PCBSize:       PCBSize,
+		VariablesSize: variablesSize,
+		Strategy:      FirstFitStrategy,
+	}
+}
+
+func (c Config) isValid() bool {
+	return c.Words > 0 && c.PCBSize >= PCBSize && c.VariablesSize >= 0 && c.PageSize >= 0 && c.PageSize <= c.Words
+}
+
+func (c Config) layout() layout {
+	return layout{pcbSize: c.PCBSize, variablesSize: c.VariablesSize}
+}
+
 // MemoryManager manager for the memory component that controls allocation and de-allocation of processes and
 // other information about processes state,PC,location,...
 type MemoryManager struct {
+	config          Config
 	ram             RAMMemory
+	strategy        AllocationStrategy
 	processLocation map[int]int
 	numberOfProcesses int
+	fragmentation   []FragmentationStats
+	frames          []frame
+	replacement     ReplacementPolicy
+	pageTables      map[int]*pageTable
+	pageStats       map[int]*PageStats
+	references      []PageRef
+	backingStore    map[int][]string
 }
 
 // Memory interface that handles addition and deletion of processes in memory
@@ -33,18 +80,46 @@ type Memory interface {
 	DeleteProcess(processId int) error
 }
 
-// NewMemoryManager factory method that creates new memory manager
-func NewMemoryManager() MemoryManager {
-	ram := RAMMemory{}
+// NewMemoryManager factory method that creates new memory manager with the given memory layout
+func NewMemoryManager(config Config) (MemoryManager, error) {
+	if !config.isValid() {
+		return MemoryManager{}, InvalidConfigErr
+	}
+	if config.Strategy == "" {
+		config.Strategy = FirstFitStrategy
+	}
+	strategy, err := NewAllocationStrategy(config.Strategy)
+	if err != nil {
+		return MemoryManager{}, err
+	}
+	if config.Replacement == "" {
+		config.Replacement = FIFOReplacement
+	}
+	replacement, err := NewReplacementPolicy(config.Replacement, nil)
+	if err != nil {
+		return MemoryManager{}, err
+	}
+	ram := newRAMMemory(config.Words)
+	var frames []frame
+	if config.PageSize > 0 {
+		frames = make([]frame, config.Words/config.PageSize)
+	}
 	return MemoryManager{
+		config:          config,
 		ram:             ram,
+		strategy:        strategy,
 		processLocation: make(map[int]int),
 		numberOfProcesses: 0,
-	}
+		frames:          frames,
+		replacement:     replacement,
+		pageTables:      make(map[int]*pageTable),
+		pageStats:       make(map[int]*PageStats),
+		backingStore:    make(map[int][]string),
+	}, nil
 }
 
-func getProcessSize(unparsedCodeSize int) int {
-	return PCBSize + unparsedCodeSize + variablesSize
+func (c Config) getProcessSize(unparsedCodeSize int) int {
+	return c.PCBSize + unparsedCodeSize + c.VariablesSize
 }
 
 func (m *MemoryManager) getNextID() int {
@@ -52,30 +127,92 @@ func (m *MemoryManager) getNextID() int {
 	return m.numberOfProcesses
 }
 
-// AddProcess creates process and save it in memory
+// AddProcess creates process and save it in the hole chosen by the allocation strategy
+// or in the backing store if the memory is paged
 func (m *MemoryManager) AddProcess(unparsedCode []string) (PCB, error) {
-	neededSize := getProcessSize(len(unparsedCode))
+	if m.config.PageSize > 0 {
+		return m.addPagedProcess(unparsedCode)
+	}
+	start, err := m.allocate(m.config.getProcessSize(len(unparsedCode)))
+	if err != nil {
+		return PCB{}, err
+	}
+	pcb := m.ram.allocateProcess(start, unparsedCode, m.getNextID(), m.config.layout())
+	m.processLocation[pcb.Id] = pcb.Start
+	m.fragmentation = append(m.fragmentation, m.Fragmentation())
+	return pcb, nil
+}
+
+// allocate returns the start of the hole chosen by the allocation strategy for the given number of words
+func (m *MemoryManager) allocate(neededSize int) (int, error) {
+	holes := m.ram.holes()
+	selected := m.strategy.Select(holes, neededSize)
+	if selected < 0 || selected >= len(holes) || !m.ram.isFree(holes[selected].Start, holes[selected].Start+neededSize-1) {
+		return 0, NotEnoughSpaceErr
+	}
+	return holes[selected].Start, nil
+}
+
+// SwapOut frees the memory of the process and returns its words, pcb first, so they can be written to disk.
+// The fields of the pcb keep their addresses until the process is swapped back in
+func (m *MemoryManager) SwapOut(process *PCB) ([]string, error) {
+	if _, isPresent := m.processLocation[process.Id]; !isPresent {
+		return nil, ProcessIdNotFoundErr
+	}
+
+	m.ram.writePCB(*process)
+	words := make([]string, process.End-process.Start+1)
+	copy(words, m.ram[process.Start:process.End+1])
+
+	delete(m.processLocation, process.Id)
+	process.delete()
+	return words, nil
+}
 
-	for i := memoryStartAddress; i <= memoryEndAddress; i++ {
-		isFree := m.ram.isFree(i, i+neededSize)
-		if isFree {
-			pcb := m.ram.allocateProcess(i, unparsedCode,m.numberOfProcesses)
-			m.processLocation[pcb.Id] = pcb.Start
-			return pcb, nil
-		}
+// SwapIn allocates the words of a swapped out process again and moves its pcb to their new location
+func (m *MemoryManager) SwapIn(process *PCB, words []string) error {
+	if len(words) != process.End-process.Start+1 || words[0] != fmt.Sprint(process.Id) {
+		return InvalidSwapErr
+	}
+	start, err := m.allocate(len(words))
+	if err != nil {
+		return err
 	}
-	return PCB{}, NotEnoughSpaceErr
+
+	copy(m.ram[start:], words)
+	offset := start - process.Start
+	process.Start += offset
+	process.End += offset
+	process.PC += offset
+	process.ram = &m.ram
+	m.ram.writePCB(*process)
+	m.processLocation[process.Id] = process.Start
+	m.fragmentation = append(m.fragmentation, m.Fragmentation())
+	return nil
+}
+
+// Fragmentation returns the current fragmentation of the free words
+func (m *MemoryManager) Fragmentation() FragmentationStats {
+	return fragmentationOf(m.ram.holes())
+}
+
+// FragmentationHistory returns the fragmentation after every allocation in order
+func (m *MemoryManager) FragmentationHistory() []FragmentationStats {
+	return m.fragmentation
 }
 
 // DeleteProcess deletes process from memory
 func (m *MemoryManager) DeleteProcess(processId int) error {
+	if m.config.PageSize > 0 {
+		return m.deletePagedProcess(processId)
+	}
 	processStartLocation := m.processLocation[processId]
 
 	if processStartLocation == 0 {
 		return ProcessIdNotFoundErr
 	}
 
-	pcb, err := m.ram.getProcessPCB(processStartLocation)
+	pcb, err := m.ram.getProcessPCB(processStartLocation, m.config.layout())
 	if err != nil {
 		return InternalMemoryErrorErr
 	}
@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"math"
 	"testing"
 )
 
@@ -12,7 +13,7 @@ var unparsedCode = []string{
 }
 
 func TestGetProcessSize(t *testing.T) {
-	found := getProcessSize(14)
+	found := DefaultConfig().getProcessSize(14)
 	expected := PCBSize + 14 + variablesSize
 
 	if found != expected {
@@ -21,14 +22,14 @@ func TestGetProcessSize(t *testing.T) {
 }
 
 func TestAddProcess(t *testing.T) {
-	memoryManager := NewMemoryManager()
+	memoryManager, _ := NewMemoryManager(DefaultConfig())
 
 	// add first process from 1 to 13
 	pcb, err := memoryManager.AddProcess(unparsedCode)
 	if pcb.Start != 1 {
 		t.Errorf("expected 1, but found %v", pcb.Start)
 	}
-	expectedEnd := pcb.Start + getProcessSize(4) - 1
+	expectedEnd := pcb.Start + DefaultConfig().getProcessSize(4) - 1
 	if pcb.End != expectedEnd {
 		t.Errorf("expected %v, but found %v", expectedEnd, pcb.End)
 	}
@@ -43,7 +44,7 @@ func TestAddProcess(t *testing.T) {
 		t.Errorf("expected 1, but found %v", pcb.Start)
 	}
 
-	expectedEnd = pcb.Start + getProcessSize(4) - 1
+	expectedEnd = pcb.Start + DefaultConfig().getProcessSize(4) - 1
 	if pcb.End != expectedEnd {
 		t.Errorf("expected %v, but found %v", expectedEnd, pcb.End)
 	}
@@ -58,7 +59,7 @@ func TestAddProcess(t *testing.T) {
 		t.Errorf("expected 1, but found %v", pcb.Start)
 	}
 
-	expectedEnd = pcb.Start + getProcessSize(4) - 1
+	expectedEnd = pcb.Start + DefaultConfig().getProcessSize(4) - 1
 	if pcb.End != expectedEnd {
 		t.Errorf("expected %v, but found %v", expectedEnd, pcb.End)
 	}
@@ -76,7 +77,7 @@ func TestAddProcess(t *testing.T) {
 }
 
 func TestDeleteProcess(t *testing.T) {
-	memoryManager := NewMemoryManager()
+	memoryManager, _ := NewMemoryManager(DefaultConfig())
 
 	pcb, err := memoryManager.AddProcess(unparsedCode)
 
@@ -109,3 +110,204 @@ func TestDeleteProcess(t *testing.T) {
 		}
 	}
 }
+
+func TestAddProcessAssignsUniqueIds(t *testing.T) {
+	memoryManager, _ := NewMemoryManager(DefaultConfig())
+
+	first, _ := memoryManager.AddProcess(unparsedCode)
+	second, _ := memoryManager.AddProcess(unparsedCode)
+
+	if first.Id != 1 {
+		t.Errorf("expected 1, but found %v", first.Id)
+	}
+	if second.Id != 2 {
+		t.Errorf("expected 2, but found %v", second.Id)
+	}
+}
+
+func TestNewMemoryManager(t *testing.T) {
+	invalidConfigs := map[string]Config{
+		"no words":                {Words: 0, PCBSize: PCBSize, VariablesSize: 3},
+		"pcb too small":           {Words: 40, PCBSize: PCBSize - 1, VariablesSize: 3},
+		"negative variables":      {Words: 40, PCBSize: PCBSize, VariablesSize: -1},
+		"page bigger than memory": {Words: 40, PCBSize: PCBSize, VariablesSize: 3, PageSize: 41},
+	}
+	for testName, config := range invalidConfigs {
+		t.Run(testName, func(t *testing.T) {
+			if _, err := NewMemoryManager(config); err != InvalidConfigErr {
+				t.Errorf("expected %v, but found %v", InvalidConfigErr, err)
+			}
+		})
+	}
+
+	t.Run("large memory with custom layout", func(t *testing.T) {
+		config := Config{Words: 2000, PCBSize: 8, VariablesSize: 5}
+		memoryManager, err := NewMemoryManager(config)
+		if err != nil {
+			t.Fatalf("expected nil, but found %v", err)
+		}
+
+		added := 0
+		for {
+			if _, err := memoryManager.AddProcess(unparsedCode); err != nil {
+				break
+			}
+			added++
+		}
+		// every process takes 17 words and the word after the last one must be free, 117 processes fit
+		if added != 117 {
+			t.Errorf("expected 117 processes, but found %v", added)
+		}
+
+		pcb, _ := memoryManager.ram.getProcessPCB(memoryManager.processLocation[1], config.layout())
+		if pcb.PC != pcb.Start+8 {
+			t.Errorf("expected %v, but found %v", pcb.Start+8, pcb.PC)
+		}
+		if pcb.VariablesCapacity() != 5 {
+			t.Errorf("expected 5, but found %v", pcb.VariablesCapacity())
+		}
+		if _, err := pcb.DeclareVariable("a"); err != nil {
+			t.Errorf("expected nil, but found %v", err)
+		}
+		instruction, _ := pcb.GetNextInstruction()
+		if instruction != unparsedCode[0] {
+			t.Errorf("expected %v, but found %v", unparsedCode[0], instruction)
+		}
+	})
+}
+
+func TestAddProcessFillingMemory(t *testing.T) {
+	memoryManager, _ := NewMemoryManager(DefaultConfig())
+	code := make([]string, DefaultConfig().Words-PCBSize-variablesSize)
+	for i := range code {
+		code[i] = "print 1"
+	}
+
+	pcb, err := memoryManager.AddProcess(code)
+	if err != nil {
+		t.Fatalf("expected nil, but found %v", err)
+	}
+	if pcb.Start != 1 || pcb.End != DefaultConfig().Words {
+		t.Errorf("expected 1 to %v, but found %v to %v", DefaultConfig().Words, pcb.Start, pcb.End)
+	}
+}
+
+func TestAddProcessWithStrategy(t *testing.T) {
+	// processes of 13 words leave holes of 13 and 16 words once the first and third are deleted
+	tests := map[string]struct {
+		strategy      string
+		expectedStart int
+	}{
+		"first fit": {strategy: FirstFitStrategy, expectedStart: 1},
+		"best fit":  {strategy: BestFitStrategy, expectedStart: 1},
+		"worst fit": {strategy: WorstFitStrategy, expectedStart: 27},
+		"next fit":  {strategy: NextFitStrategy, expectedStart: 27},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			memoryManager, err := NewMemoryManager(Config{Words: 42, PCBSize: PCBSize, VariablesSize: variablesSize, Strategy: test.strategy})
+			if err != nil {
+				t.Fatalf("expected nil, but found %v", err)
+			}
+			first, _ := memoryManager.AddProcess(unparsedCode)
+			memoryManager.AddProcess(unparsedCode)
+			third, _ := memoryManager.AddProcess(unparsedCode)
+			memoryManager.DeleteProcess(first.Id)
+			memoryManager.DeleteProcess(third.Id)
+
+			found, err := memoryManager.AddProcess([]string{"assign x 4"})
+			if err != nil {
+				t.Fatalf("expected nil, but found %v", err)
+			}
+			if found.Start != test.expectedStart {
+				t.Errorf("expected %v, but found %v", test.expectedStart, found.Start)
+			}
+		})
+	}
+
+	t.Run("unknown replacement policy", func(t *testing.T) {
+		if _, err := NewMemoryManager(Config{Words: 40, PCBSize: PCBSize, VariablesSize: variablesSize, PageSize: 4, Replacement: "random"}); err != UnknownReplacementErr {
+			t.Errorf("expected %v, but found %v", UnknownReplacementErr, err)
+		}
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		if _, err := NewMemoryManager(Config{Words: 40, PCBSize: PCBSize, VariablesSize: variablesSize, Strategy: "buddy"}); err != UnknownStrategyErr {
+			t.Errorf("expected %v, but found %v", UnknownStrategyErr, err)
+		}
+	})
+}
+
+func TestFragmentationHistory(t *testing.T) {
+	memoryManager, _ := NewMemoryManager(DefaultConfig())
+
+	first, _ := memoryManager.AddProcess(unparsedCode)
+	memoryManager.AddProcess(unparsedCode)
+	memoryManager.DeleteProcess(first.Id)
+	memoryManager.AddProcess([]string{"assign x 4"})
+
+	expected := []FragmentationStats{
+		{LargestHole: 27, TotalFree: 27},
+		{LargestHole: 14, TotalFree: 14},
+		{LargestHole: 14, TotalFree: 17, ExternalFragmentation: 1 - 14.0/17.0},
+	}
+	found := memoryManager.FragmentationHistory()
+	if len(found) != len(expected) {
+		t.Fatalf("expected %v, but found %v", expected, found)
+	}
+	for i := range expected {
+		if found[i].LargestHole != expected[i].LargestHole || found[i].TotalFree != expected[i].TotalFree ||
+			math.Abs(found[i].ExternalFragmentation-expected[i].ExternalFragmentation) > 1e-9 {
+			t.Errorf("allocation %v: expected %v, but found %v", i, expected[i], found[i])
+		}
+	}
+}
+
+func TestSwap(t *testing.T) {
+	memoryManager, _ := NewMemoryManager(DefaultConfig())
+	first, _ := memoryManager.AddProcess(unparsedCode)
+	second, _ := memoryManager.AddProcess(unparsedCode)
+	second.IncrementPC()
+	second.DeclareVariable("x")
+	second.SetVariable(0, StringOf("hello"))
+
+	words, err := memoryManager.SwapOut(&second)
+	if err != nil {
+		t.Fatalf("expected nil, but found %v", err)
+	}
+	if len(words) != DefaultConfig().getProcessSize(4) {
+		t.Errorf("expected %v words, but found %v", DefaultConfig().getProcessSize(4), len(words))
+	}
+	if words[2] != "21" {
+		t.Errorf("expected the pc 21 in the swapped pcb, but found %v", words[2])
+	}
+	for i := second.Start; i <= second.End; i++ {
+		if memoryManager.ram[i] != "" {
+			t.Errorf("at %v: expected empty line but found %v", i, memoryManager.ram[i])
+		}
+	}
+	if _, err := memoryManager.SwapOut(&second); err != ProcessIdNotFoundErr {
+		t.Errorf("expected %v, but found %v", ProcessIdNotFoundErr, err)
+	}
+
+	// the process is swapped back in at the start of the memory
+	memoryManager.DeleteProcess(first.Id)
+	if err := memoryManager.SwapIn(&first, words); err != InvalidSwapErr {
+		t.Errorf("expected %v, but found %v", InvalidSwapErr, err)
+	}
+	if err := memoryManager.SwapIn(&second, words); err != nil {
+		t.Fatalf("expected nil, but found %v", err)
+	}
+	if second.Start != 1 || second.End != 13 || second.PC != 8 {
+		t.Errorf("expected start 1, end 13 and pc 8, but found %v, %v and %v", second.Start, second.End, second.PC)
+	}
+	if memoryManager.processLocation[second.Id] != 1 {
+		t.Errorf("expected 1, but found %v", memoryManager.processLocation[second.Id])
+	}
+	if value, _ := second.GetVariable(0); value != StringOf("hello") {
+		t.Errorf("expected %v, but found %v", StringOf("hello"), value)
+	}
+	if instruction, _ := second.GetNextInstruction(); instruction != unparsedCode[1] {
+		t.Errorf("expected %v, but found %v", unparsedCode[1], instruction)
+	}
+}
@@ -0,0 +1,302 @@
+package memory
+
+import (
+	"fmt"
+)
+
+// PageFaultError is returned when a process accesses a page that isn't in a frame.
+type PageFaultError struct {
+	Id   int
+	Page int
+}
+
+func (e *PageFaultError) Error() string {
+	return fmt.Sprintf("page fault on page %d of process %d", e.Page, e.Id)
+}
+
+// pageTableEntry maps a page of a process to the frame it's loaded in
+type pageTableEntry struct {
+	frame   int
+	present bool
+}
+
+// pageTable maps the virtual addresses of a process to the frames of the RAM
+type pageTable struct {
+	pageSize int
+	entries  []pageTableEntry
+	// dataPages are the pages of the data words the next instruction accesses
+	dataPages []int
+}
+
+// frame is the page loaded in a frame of the RAM
+type frame struct {
+	id   int
+	page int
+	used bool
+}
+
+// PageStats counts how the page references of a process were served.
+type PageStats struct {
+	Hits   int
+	Faults int
+	// Evictions is the number of pages of the process that were evicted to load other pages
+	Evictions int
+}
+
+func newPageTable(pageSize int, words int) *pageTable {
+	return &pageTable{
+		pageSize: pageSize,
+		entries:  make([]pageTableEntry, (words+pageSize-1)/pageSize),
+	}
+}
+
+// translate returns the physical address of the virtual address, the address is returned as it is
+// if the process isn't paged
+func (p *PCB) translate(address int) (int, error) {
+	if p.pageTable == nil {
+		return address, nil
+	}
+	page, offset := address/p.pageTable.pageSize, address%p.pageTable.pageSize
+	if address < 0 || page >= len(p.pageTable.entries) {
+		return 0, ProtectionErr
+	}
+	entry := p.pageTable.entries[page]
+	if !entry.present {
+		return 0, &PageFaultError{Id: p.Id, Page: page}
+	}
+	return memoryStartAddress + entry.frame*p.pageTable.pageSize + offset, nil
+}
+
+// workingSet returns the pages the next instruction accesses in order, the page of the instruction
+// and the pages of the data words set by SetInstructionVariables
+func (p *PCB) workingSet() []int {
+	if p.pageTable == nil {
+		return nil
+	}
+	pages := []int{}
+	if p.PC < p.getVariablesAddress() {
+		pages = append(pages, p.PC/p.pageTable.pageSize)
+	}
+	for _, page := range p.pageTable.dataPages {
+		if !containsPage(pages, page) {
+			pages = append(pages, page)
+		}
+	}
+	return pages
+}
+
+func containsPage(pages []int, page int) bool {
+	for _, candidate := range pages {
+		if candidate == page {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPages returns a *PageFaultError for the first page the next instruction can access that isn't in a frame,
+// so the fault is resolved before the instruction is executed
+func (p *PCB) CheckPages() error {
+	for _, page := range p.workingSet() {
+		if !p.pageTable.entries[page].present {
+			return &PageFaultError{Id: p.Id, Page: page}
+		}
+	}
+	return nil
+}
+
+// addPagedProcess writes the words of the process to the backing store without loading any of its pages,
+// the addresses of the pcb are virtual addresses starting at zero
+func (m *MemoryManager) addPagedProcess(unparsedCode []string) (PCB, error) {
+	words := make(RAMMemory, m.config.getProcessSize(len(unparsedCode)))
+	pcb := words.allocateProcess(0, unparsedCode, m.getNextID(), m.config.layout())
+	pcb.ram = &m.ram
+	pcb.pageTable = newPageTable(m.config.PageSize, len(words))
+
+	m.backingStore[pcb.Id] = []string(words)
+	m.pageTables[pcb.Id] = pcb.pageTable
+	return pcb, nil
+}
+
+// SetInstructionVariables sets the data pages in the working set of the process to the pages of the variables
+// the next instruction accesses, the variables function returns their names in the instruction. A variable that
+// isn't declared yet is declared in the first free data word so the page of that word is used. The symbol table
+// is read from the frames and the backing store without referencing any page. Processes that aren't paged are ignored.
+func (m *MemoryManager) SetInstructionVariables(process *PCB, variables func(instruction string) []string) {
+	if process.pageTable == nil {
+		return
+	}
+	process.pageTable.dataPages = nil
+	if process.PC >= process.getVariablesAddress() {
+		return
+	}
+
+	declared, firstFree := map[string]int{}, -1
+	for location := 0; location < process.VariablesCapacity(); location++ {
+		name, _, _ := decodeVariable(m.pagedWord(process, process.getVariablesAddress()+location))
+		if name == "" && firstFree < 0 {
+			firstFree = location
+		} else if name != "" {
+			declared[name] = location
+		}
+	}
+	for _, name := range variables(m.pagedWord(process, process.PC)) {
+		location, isDeclared := declared[name]
+		if !isDeclared && firstFree < 0 {
+			continue
+		}
+		if !isDeclared {
+			location = firstFree
+		}
+		page := (process.getVariablesAddress() + location) / process.pageTable.pageSize
+		if !containsPage(process.pageTable.dataPages, page) {
+			process.pageTable.dataPages = append(process.pageTable.dataPages, page)
+		}
+	}
+}
+
+// pagedWord returns the word at the virtual address of the paged process from its frame,
+// or from the backing store if its page isn't loaded
+func (m *MemoryManager) pagedWord(process *PCB, address int) string {
+	if physicalAddress, err := process.translate(address); err == nil {
+		return m.ram[physicalAddress]
+	}
+	return m.backingStore[process.Id][address]
+}
+
+// ReferencePages references the pages the next instruction of the process accesses, the references are
+// counted as hits or faults and recorded in the reference string. The faulted pages are loaded by LoadPage
+func (m *MemoryManager) ReferencePages(process *PCB) {
+	for _, page := range process.workingSet() {
+		stats := m.stats(process.Id)
+		if process.pageTable.entries[page].present {
+			stats.Hits++
+		} else {
+			stats.Faults++
+		}
+		reference := PageRef{Id: process.Id, Page: page}
+		m.replacement.Reference(reference)
+		m.references = append(m.references, reference)
+	}
+}
+
+// LoadPage resolves the page fault of the process by loading the page from the backing store into a frame.
+// When every frame is used, the replacement policy chooses the evicted page, pages the next instruction
+// of the process can access are never evicted
+func (m *MemoryManager) LoadPage(process *PCB, page int) error {
+	if process.pageTable == nil || page < 0 || page >= len(process.pageTable.entries) {
+		return ProtectionErr
+	}
+	if process.pageTable.entries[page].present {
+		return nil
+	}
+
+	index, err := m.freeFrame(process)
+	if err != nil {
+		return err
+	}
+	copy(m.ram[m.frameAddress(index):], m.pageWords(process.Id, page))
+	m.frames[index] = frame{id: process.Id, page: page, used: true}
+	m.replacement.Load(PageRef{Id: process.Id, Page: page})
+	process.pageTable.entries[page] = pageTableEntry{frame: index, present: true}
+	return nil
+}
+
+// freeFrame returns a frame that isn't used, evicting a page if needed
+func (m *MemoryManager) freeFrame(process *PCB) (int, error) {
+	for index, frame := range m.frames {
+		if !frame.used {
+			return index, nil
+		}
+	}
+
+	pinned := map[int]bool{}
+	for _, page := range process.workingSet() {
+		pinned[page] = true
+	}
+	candidates, candidateFrames := []PageRef{}, []int{}
+	for index, frame := range m.frames {
+		if frame.id == process.Id && pinned[frame.page] {
+			continue
+		}
+		candidates = append(candidates, PageRef{Id: frame.id, Page: frame.page})
+		candidateFrames = append(candidateFrames, index)
+	}
+	if len(candidates) == 0 {
+		return 0, NotEnoughSpaceErr
+	}
+	index := candidateFrames[m.replacement.Victim(candidates)]
+	m.stats(m.frames[index].id).Evictions++
+	m.evict(index)
+	return index, nil
+}
+
+// evict writes the page in the frame back to the backing store and frees the frame
+func (m *MemoryManager) evict(index int) {
+	victim := m.frames[index]
+	address := m.frameAddress(index)
+	copy(m.pageWords(victim.id, victim.page), m.ram[address:])
+	for offset := 0; offset < m.config.PageSize; offset++ {
+		m.ram[address+offset] = ""
+	}
+	m.pageTables[victim.id].entries[victim.page].present = false
+	m.replacement.Remove(PageRef{Id: victim.id, Page: victim.page})
+	m.frames[index] = frame{}
+}
+
+// deletePagedProcess frees the frames and the backing store of the process
+func (m *MemoryManager) deletePagedProcess(processId int) error {
+	table, isPresent := m.pageTables[processId]
+	if !isPresent {
+		return ProcessIdNotFoundErr
+	}
+	for page, entry := range table.entries {
+		if !entry.present {
+			continue
+		}
+		address := m.frameAddress(entry.frame)
+		for offset := 0; offset < m.config.PageSize; offset++ {
+			m.ram[address+offset] = ""
+		}
+		m.frames[entry.frame] = frame{}
+		m.replacement.Remove(PageRef{Id: processId, Page: page})
+	}
+	delete(m.pageTables, processId)
+	delete(m.backingStore, processId)
+	return nil
+}
+
+// SetReplacementPolicy replaces the policy that chooses the evicted pages, the pages loaded before
+// aren't known to the new policy so it should be set before any page is loaded
+func (m *MemoryManager) SetReplacementPolicy(policy ReplacementPolicy) {
+	m.replacement = policy
+}
+
+// PageStats returns the page references counters of the process, they're kept after the process is deleted
+func (m *MemoryManager) PageStats(processId int) PageStats {
+	return *m.stats(processId)
+}
+
+// References returns the reference string, every page referenced by the executed instructions in order
+func (m *MemoryManager) References() []PageRef {
+	return m.references
+}
+
+func (m *MemoryManager) stats(processId int) *PageStats {
+	if _, isPresent := m.pageStats[processId]; !isPresent {
+		m.pageStats[processId] = &PageStats{}
+	}
+	return m.pageStats[processId]
+}
+
+// pageWords returns the words of the page in the backing store
+func (m *MemoryManager) pageWords(processId int, page int) []string {
+	words := m.backingStore[processId]
+	start := page * m.config.PageSize
+	end := min(start+m.config.PageSize, len(words))
+	return words[start:end]
+}
+
+func (m *MemoryManager) frameAddress(index int) int {
+	return memoryStartAddress + index*m.config.PageSize
+}
@@ -0,0 +1,228 @@
+package memory
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newPagedMemoryManager(t *testing.T, words int) MemoryManager {
+	t.Helper()
+	memoryManager, err := NewMemoryManager(Config{Words: words, PCBSize: PCBSize, VariablesSize: variablesSize, PageSize: 4})
+	if err != nil {
+		t.Fatalf("expected nil, but found %v", err)
+	}
+	return memoryManager
+}
+
+// declaredVariable returns the variable declared by the `assign x 4` instructions of the test processes
+func declaredVariable(instruction string) []string {
+	return strings.Fields(instruction)[1:2]
+}
+
+// referenceAndLoad references the pages the next instruction of the process accesses and loads the faulted ones
+func referenceAndLoad(t *testing.T, memoryManager *MemoryManager, process *PCB) {
+	t.Helper()
+	memoryManager.SetInstructionVariables(process, declaredVariable)
+	memoryManager.ReferencePages(process)
+	for _, page := range process.workingSet() {
+		if err := memoryManager.LoadPage(process, page); err != nil {
+			t.Fatalf("expected nil, but found %v", err)
+		}
+	}
+}
+
+func TestAddPagedProcess(t *testing.T) {
+	memoryManager := newPagedMemoryManager(t, 8)
+
+	// the process takes 13 words in 4 pages, more than the 2 frames of the memory
+	pcb, err := memoryManager.AddProcess(unparsedCode)
+	if err != nil {
+		t.Fatalf("expected nil, but found %v", err)
+	}
+	if pcb.Start != 0 || pcb.End != 12 || pcb.PC != PCBSize {
+		t.Errorf("expected start 0, end 12 and pc %v, but found %v, %v and %v", PCBSize, pcb.Start, pcb.End, pcb.PC)
+	}
+	if len(pcb.pageTable.entries) != 4 {
+		t.Errorf("expected 4 pages, but found %v", len(pcb.pageTable.entries))
+	}
+
+	var pageFault *PageFaultError
+	if _, err := pcb.GetNextInstruction(); !errors.As(err, &pageFault) || pageFault.Page != 1 {
+		t.Fatalf("expected page fault on page 1, but found %v", err)
+	}
+	if err := pcb.CheckPages(); !errors.As(err, &pageFault) || pageFault.Page != 1 {
+		t.Fatalf("expected page fault on page 1, but found %v", err)
+	}
+}
+
+func TestLoadPage(t *testing.T) {
+	memoryManager := newPagedMemoryManager(t, 12)
+	first, _ := memoryManager.AddProcess(unparsedCode)
+	second, _ := memoryManager.AddProcess(unparsedCode)
+
+	if err := memoryManager.LoadPage(&first, 1); err != nil {
+		t.Fatalf("expected nil, but found %v", err)
+	}
+	if instruction, err := first.GetNextInstruction(); err != nil || instruction != unparsedCode[0] {
+		t.Errorf("expected %v, but found %v and %v", unparsedCode[0], instruction, err)
+	}
+
+	// page 1 holds the next instruction and page 2 the free data word it declares x in, so page 0 is evicted
+	// even though it's loaded after them
+	memoryManager.SetInstructionVariables(&first, declaredVariable)
+	memoryManager.LoadPage(&first, 2)
+	memoryManager.LoadPage(&first, 0)
+	location, _ := first.DeclareVariable("x")
+	first.SetVariable(location, IntegerOf(7))
+	if err := memoryManager.LoadPage(&first, 3); err != nil {
+		t.Fatalf("expected nil, but found %v", err)
+	}
+	if first.pageTable.entries[0].present || !first.pageTable.entries[1].present {
+		t.Errorf("expected page 0 to be evicted instead of page 1")
+	}
+
+	// the pages of other processes are evicted in the order they were loaded
+	memoryManager.LoadPage(&second, 1)
+	memoryManager.LoadPage(&second, 2)
+	if first.pageTable.entries[1].present || first.pageTable.entries[2].present || !first.pageTable.entries[3].present {
+		t.Errorf("expected pages 1 and 2 to be evicted")
+	}
+	if _, err := first.GetVariable(location); !errors.As(err, new(*PageFaultError)) {
+		t.Errorf("expected page fault, but found %v", err)
+	}
+
+	// the evicted page is written back to the backing store
+	memoryManager.LoadPage(&first, 2)
+	location, isDeclared := first.FindVariable("x")
+	if value, _ := first.GetVariable(location); !isDeclared || value != IntegerOf(7) {
+		t.Errorf("expected %v, but found %v", IntegerOf(7), value)
+	}
+
+	if err := memoryManager.LoadPage(&first, 4); err != ProtectionErr {
+		t.Errorf("expected %v, but found %v", ProtectionErr, err)
+	}
+}
+
+func TestLoadPageWithoutFreeFrame(t *testing.T) {
+	memoryManager := newPagedMemoryManager(t, 4)
+	pcb, _ := memoryManager.AddProcess(unparsedCode)
+
+	// the page of the instruction can't be evicted to load another page in the single frame
+	memoryManager.LoadPage(&pcb, 1)
+	if err := memoryManager.LoadPage(&pcb, 2); err != NotEnoughSpaceErr {
+		t.Errorf("expected %v, but found %v", NotEnoughSpaceErr, err)
+	}
+}
+
+func TestDeletePagedProcess(t *testing.T) {
+	memoryManager := newPagedMemoryManager(t, 8)
+	pcb, _ := memoryManager.AddProcess(unparsedCode)
+	memoryManager.LoadPage(&pcb, 1)
+
+	if err := memoryManager.DeleteProcess(pcb.Id); err != nil {
+		t.Fatalf("expected nil, but found %v", err)
+	}
+	for i := memoryStartAddress; i <= memoryManager.ram.endAddress(); i++ {
+		if memoryManager.ram[i] != "" {
+			t.Errorf("at %v: expected empty line but found %v", i, memoryManager.ram[i])
+		}
+	}
+	for index, frame := range memoryManager.frames {
+		if frame.used {
+			t.Errorf("frame %v: expected free frame, but found page %v of process %v", index, frame.page, frame.id)
+		}
+	}
+	if err := memoryManager.DeleteProcess(pcb.Id); err != ProcessIdNotFoundErr {
+		t.Errorf("expected %v, but found %v", ProcessIdNotFoundErr, err)
+	}
+}
+
+func TestSetInstructionVariables(t *testing.T) {
+	memoryManager := newPagedMemoryManager(t, 8)
+	pcb, _ := memoryManager.AddProcess(unparsedCode)
+	// z is declared in the last data word on page 3, new variables are declared in the first free word on page 2
+	memoryManager.backingStore[pcb.Id][12] = encodeVariable("z", IntegerOf(1))
+
+	tests := map[string]struct {
+		names    []string
+		expected []int
+	}{
+		"declared variable": {names: []string{"z"}, expected: []int{1, 3}},
+		"new variable":      {names: []string{"w", "v"}, expected: []int{1, 2}},
+		"both variables":    {names: []string{"z", "w", "z"}, expected: []int{1, 3, 2}},
+		"no variables":      {names: []string{}, expected: []int{1}},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			memoryManager.SetInstructionVariables(&pcb, func(instruction string) []string {
+				if instruction != unparsedCode[0] {
+					t.Errorf("expected %v, but found %v", unparsedCode[0], instruction)
+				}
+				return test.names
+			})
+			if found := pcb.workingSet(); !reflect.DeepEqual(test.expected, found) {
+				t.Errorf("expected %v, but found %v", test.expected, found)
+			}
+		})
+	}
+}
+
+func TestReferencePages(t *testing.T) {
+	memoryManager := newPagedMemoryManager(t, 12)
+	first, _ := memoryManager.AddProcess(unparsedCode)
+	second, _ := memoryManager.AddProcess(unparsedCode)
+
+	// referencing a page doesn't load it
+	memoryManager.SetInstructionVariables(&first, declaredVariable)
+	memoryManager.ReferencePages(&first)
+	if first.pageTable.entries[1].present || first.pageTable.entries[2].present {
+		t.Fatalf("expected the pages of the first process not to be loaded")
+	}
+	for _, page := range []int{1, 2} {
+		memoryManager.LoadPage(&first, page)
+	}
+
+	// every instruction references the page of the instruction and page 2 of the data word x is declared in
+	first.IncrementPC()
+	referenceAndLoad(t, &memoryManager, &first)
+	// the second process takes the last free frame and evicts the page the first process loaded first
+	referenceAndLoad(t, &memoryManager, &second)
+
+	if found := memoryManager.PageStats(first.Id); found != (PageStats{Hits: 2, Faults: 2, Evictions: 1}) {
+		t.Errorf("expected 2 hits, 2 faults and 1 eviction, but found %+v", found)
+	}
+	if found := memoryManager.PageStats(second.Id); found != (PageStats{Faults: 2}) {
+		t.Errorf("expected 2 faults, but found %+v", found)
+	}
+	if first.pageTable.entries[1].present || !first.pageTable.entries[2].present {
+		t.Errorf("expected page 1 of the first process to be evicted")
+	}
+
+	expected := []PageRef{{1, 1}, {1, 2}, {1, 1}, {1, 2}, {2, 1}, {2, 2}}
+	if !reflect.DeepEqual(expected, memoryManager.References()) {
+		t.Errorf("expected %v, but found %v", expected, memoryManager.References())
+	}
+
+	// the counters are kept after the process is deleted
+	memoryManager.DeleteProcess(first.Id)
+	if found := memoryManager.PageStats(first.Id); found.Faults != 2 {
+		t.Errorf("expected 2 faults, but found %v", found.Faults)
+	}
+}
+
+func TestReplacementPolicyEvictsPages(t *testing.T) {
+	memoryManager, _ := NewMemoryManager(Config{Words: 12, PCBSize: PCBSize, VariablesSize: variablesSize, PageSize: 4, Replacement: LRUReplacement})
+	first, _ := memoryManager.AddProcess(unparsedCode)
+	second, _ := memoryManager.AddProcess(unparsedCode)
+
+	// page 1 is referenced again after page 2 so lru evicts page 2, fifo would evict page 1
+	referenceAndLoad(t, &memoryManager, &first)
+	memoryManager.replacement.Reference(PageRef{Id: first.Id, Page: 1})
+	referenceAndLoad(t, &memoryManager, &second)
+
+	if !first.pageTable.entries[1].present || first.pageTable.entries[2].present {
+		t.Errorf("expected page 2 of the first process to be evicted")
+	}
+}
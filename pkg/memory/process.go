@@ -2,7 +2,6 @@ package memory
 
 import (
 	"errors"
-	"fmt"
 )
 
 type STATE string
@@ -17,13 +16,31 @@ const (
 var (
 	EndOfInstructionsErr = errors.New("reached end of instructions")
 	ProtectionErr        = errors.New("not allowed to access that part of memory")
+	TooManyVariablesErr  = errors.New("no free data word left for a new variable")
 )
 
 type PCBManager interface {
 	GetNextInstruction() (string, error)
 	IncrementPC() error
-	SetDataWord(virtualLocation int, data int) error
-	GetDataWord(virtualLocation int) (string, error)
+	SetPC(virtualLocation int) error
+	SetVariable(virtualLocation int, value Value) error
+	GetVariable(virtualLocation int) (Value, error)
+	FindVariable(name string) (int, bool)
+	DeclareVariable(name string) (int, error)
+}
+
+// layout is the number of words reserved for the pcb and the variables of a process
+type layout struct {
+	pcbSize       int
+	variablesSize int
+}
+
+// reservedPCBSize returns the words reserved for the pcb, a pcb that wasn't allocated by the memory only has its fields
+func (l layout) reservedPCBSize() int {
+	if l.pcbSize == 0 {
+		return PCBSize
+	}
+	return l.pcbSize
 }
 
 type PCB struct {
@@ -33,7 +50,14 @@ type PCB struct {
 	Start    int
 	End      int
 	CodeSize int
-	ram      *RAMMemory
+	// Priority is the static scheduling priority, lower values run first
+	Priority int
+	// RemainingQuantum is the number of instructions left in the current time slice
+	RemainingQuantum int
+	layout           layout
+	ram              *RAMMemory
+	// pageTable maps the addresses of a paged process, the addresses are physical if it's nil
+	pageTable *pageTable
 }
 
 func (p *PCB) getPCBAddress() int {
@@ -41,7 +65,7 @@ func (p *PCB) getPCBAddress() int {
 }
 
 func (p *PCB) getUnparsedCodeAddress() int {
-	return p.getPCBAddress() + PCBSize
+	return p.getPCBAddress() + p.layout.reservedPCBSize()
 }
 
 func (p *PCB) getVariablesAddress() int {
@@ -50,7 +74,7 @@ func (p *PCB) getVariablesAddress() int {
 
 func (p *PCB) delete() {
 	for i := p.Start; i <= p.End; i++ {
-		p.ram[i] = ""
+		(*p.ram)[i] = ""
 	}
 }
 
@@ -60,10 +84,24 @@ func (p *PCB) GetNextInstruction() (string, error) {
 		return "", EndOfInstructionsErr
 	}
 
-	instruction := p.ram[p.PC]
+	physicalLocation, err := p.translate(p.PC)
+	if err != nil {
+		return "", err
+	}
+	instruction := (*p.ram)[physicalLocation]
 	return instruction, nil
 }
 
+// ResetPC moves the program counter back to the first instruction of the process
+func (p *PCB) ResetPC() {
+	p.PC = p.getUnparsedCodeAddress()
+}
+
+// RemainingInstructions returns the number of instructions the process didn't execute yet
+func (p *PCB) RemainingInstructions() int {
+	return p.getVariablesAddress() - p.PC
+}
+
 func (p *PCB) IncrementPC() error {
 	_, err := p.GetNextInstruction()
 	if err != nil {
@@ -74,24 +112,116 @@ func (p *PCB) IncrementPC() error {
 	return nil
 }
 
-// TODO: Modify the data field to string
-// SetDataWord put data in memory in the specified location
-func (p *PCB) SetDataWord(virtualLocation int, data int) error {
-	if virtualLocation < 0 || virtualLocation > 3 {
+// SetPC moves the program counter to the instruction at the given offset in the code of the process,
+// the offset right after the last instruction ends the process
+func (p *PCB) SetPC(virtualLocation int) error {
+	if virtualLocation < 0 || virtualLocation > p.CodeSize {
 		return ProtectionErr
 	}
 
-	physicalLocation := virtualLocation + p.getVariablesAddress()
-	p.ram[physicalLocation] = fmt.Sprint(data)
+	p.PC = p.getUnparsedCodeAddress() + virtualLocation
 	return nil
 }
 
-// GetDataWord retrieve data from memory from the specified location
-func (p *PCB) GetDataWord(virtualLocation int) (string, error) {
-	if virtualLocation < 0 || virtualLocation > 2 {
+// setDataWord put a raw word in the specified data location, the typed variables are written with SetVariable
+func (p *PCB) setDataWord(virtualLocation int, data string) error {
+	if virtualLocation < 0 || virtualLocation >= p.layout.variablesSize {
+		return ProtectionErr
+	}
+
+	physicalLocation, err := p.translate(virtualLocation + p.getVariablesAddress())
+	if err != nil {
+		return err
+	}
+	(*p.ram)[physicalLocation] = data
+	return nil
+}
+
+// getDataWord retrieve the raw word from the specified data location
+func (p *PCB) getDataWord(virtualLocation int) (string, error) {
+	if virtualLocation < 0 || virtualLocation >= p.layout.variablesSize {
 		return "", ProtectionErr
 	}
 
-	physicalLocation := virtualLocation + p.getVariablesAddress()
-	return p.ram[physicalLocation], nil
+	physicalLocation, err := p.translate(virtualLocation + p.getVariablesAddress())
+	if err != nil {
+		return "", err
+	}
+	return (*p.ram)[physicalLocation], nil
+}
+
+// VariablesCapacity returns the number of variables the process can declare
+func (p *PCB) VariablesCapacity() int {
+	return p.layout.variablesSize
+}
+
+// FindVariable returns the location of the variable with the given name in the symbol table of the process,
+// the symbol table is the names stored with the values in the data words
+func (p *PCB) FindVariable(name string) (int, bool) {
+	for virtualLocation := 0; virtualLocation < p.VariablesCapacity(); virtualLocation++ {
+		physicalLocation, err := p.translate(virtualLocation + p.getVariablesAddress())
+		if err != nil {
+			continue
+		}
+		variableName, _, _ := p.ram.readVariable(physicalLocation)
+		if variableName != "" && variableName == name {
+			return virtualLocation, true
+		}
+	}
+	return 0, false
+}
+
+// DeclareVariable returns the location of the variable with the given name,
+// declaring it in the first free data word with a zero value if it isn't declared yet
+func (p *PCB) DeclareVariable(name string) (int, error) {
+	if !isValidVariableName(name) {
+		return 0, InvalidVariableNameErr
+	}
+	if virtualLocation, isDeclared := p.FindVariable(name); isDeclared {
+		return virtualLocation, nil
+	}
+	for virtualLocation := 0; virtualLocation < p.VariablesCapacity(); virtualLocation++ {
+		physicalLocation, err := p.translate(virtualLocation + p.getVariablesAddress())
+		if errors.As(err, new(*PageFaultError)) {
+			// the words before the first free one are declared, their pages don't have to be loaded
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if variableName, _, _ := p.ram.readVariable(physicalLocation); variableName == "" {
+			p.ram.writeVariable(physicalLocation, name, IntegerOf(0))
+			return virtualLocation, nil
+		}
+	}
+	return 0, TooManyVariablesErr
+}
+
+// SetVariable put typed value in memory in the specified location, keeping the name of the variable
+func (p *PCB) SetVariable(virtualLocation int, value Value) error {
+	if virtualLocation < 0 || virtualLocation >= p.layout.variablesSize {
+		return ProtectionErr
+	}
+
+	physicalLocation, err := p.translate(virtualLocation + p.getVariablesAddress())
+	if err != nil {
+		return err
+	}
+	name, _, _ := p.ram.readVariable(physicalLocation)
+	p.ram.writeVariable(physicalLocation, name, value)
+	return nil
+}
+
+// GetVariable retrieve typed value from memory from the specified location
+func (p *PCB) GetVariable(virtualLocation int) (Value, error) {
+	if virtualLocation < 0 || virtualLocation >= p.layout.variablesSize {
+		return Value{}, ProtectionErr
+	}
+
+	physicalLocation, err := p.translate(virtualLocation + p.getVariablesAddress())
+	if err != nil {
+		return Value{}, err
+	}
+	_, value, err := p.ram.readVariable(physicalLocation)
+	return value, err
 }
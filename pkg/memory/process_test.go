@@ -6,6 +6,7 @@ import (
 
 func TestGetUnparsedCodeAddress(t *testing.T) {
 	process := PCB{
+		layout:   DefaultConfig().layout(),
 		Start: 10,
 	}
 
@@ -18,6 +19,7 @@ func TestGetUnparsedCodeAddress(t *testing.T) {
 
 func TestGetVariablesAddress(t *testing.T) {
 	process := PCB{
+		layout:   DefaultConfig().layout(),
 		Start:    10,
 		CodeSize: 6,
 	}
@@ -32,8 +34,9 @@ func TestGetVariablesAddress(t *testing.T) {
 func TestGetNextInstruction(t *testing.T) {
 
 	t.Run("normal case return next instruction", func(t *testing.T) {
-		var ram RAMMemory
+		ram := newRAMMemory(memoryWords)
 		process := PCB{
+			layout:   DefaultConfig().layout(),
 			Start:    10,
 			CodeSize: 6,
 			PC:       16,
@@ -53,8 +56,9 @@ func TestGetNextInstruction(t *testing.T) {
 	})
 
 	t.Run("return error when reaching end of instructions", func(t *testing.T) {
-		var ram RAMMemory
+		ram := newRAMMemory(memoryWords)
 		process := PCB{
+			layout:   DefaultConfig().layout(),
 			Start:    10,
 			CodeSize: 6,
 			PC:       22,
@@ -74,8 +78,9 @@ func TestGetNextInstruction(t *testing.T) {
 
 func TestSetDataWord(t *testing.T) {
 	t.Run("normal case return data at virtual location 2", func(t *testing.T) {
-		var ram RAMMemory
+		ram := newRAMMemory(memoryWords)
 		process := PCB{
+			layout:   DefaultConfig().layout(),
 			Start:    10,
 			CodeSize: 6,
 			PC:       16,
@@ -86,7 +91,7 @@ func TestSetDataWord(t *testing.T) {
 		ram[location]="13"
 		ram[location+1]="14"
 		
-		data,err:=process.GetDataWord(0)
+		data,err:=process.getDataWord(0)
 		
 		if err != nil {
 			t.Errorf("expected nil found %v", err)
@@ -96,7 +101,7 @@ func TestSetDataWord(t *testing.T) {
 			t.Errorf("expected 13 found %v", data)
 		}
 
-		data,err=process.GetDataWord(1)
+		data,err=process.getDataWord(1)
 		if err != nil {
 			t.Errorf("expected nil found %v", err)
 		}
@@ -107,15 +112,16 @@ func TestSetDataWord(t *testing.T) {
 	})
 
 	t.Run("return error when virtual address is not between 0 and 2", func(t *testing.T) {
-		var ram RAMMemory
+		ram := newRAMMemory(memoryWords)
 		process := PCB{
+			layout:   DefaultConfig().layout(),
 			Start:    10,
 			CodeSize: 6,
 			PC:       16,
 			ram:      &ram,
 		}
 		
-		data,err:=process.GetDataWord(-1)
+		data,err:=process.getDataWord(-1)
 		
 		if err != ProtectionErr {
 			t.Errorf("expected %v found %v", ProtectionErr,err)
@@ -125,7 +131,7 @@ func TestSetDataWord(t *testing.T) {
 			t.Errorf("expected empty string found %v", data)
 		}
 
-		data,err=process.GetDataWord(3)
+		data,err=process.getDataWord(3)
 		if err != ProtectionErr {
 			t.Errorf("expected %v found %v", ProtectionErr,err)
 		}
@@ -139,8 +145,9 @@ func TestSetDataWord(t *testing.T) {
 
 func TestIncrementPC(t *testing.T) {
 	t.Run("normal case increment pc", func(t *testing.T) {
-		var ram RAMMemory
+		ram := newRAMMemory(memoryWords)
 		process := PCB{
+			layout:   DefaultConfig().layout(),
 			Start:    10,
 			CodeSize: 6,
 			PC:       16,
@@ -159,8 +166,9 @@ func TestIncrementPC(t *testing.T) {
 	})
 
 	t.Run("reached end of instructions returns error", func(t *testing.T) {
-		var ram RAMMemory
+		ram := newRAMMemory(memoryWords)
 		process := PCB{
+			layout:   DefaultConfig().layout(),
 			Start:    10,
 			CodeSize: 6,
 			PC:       22,
@@ -177,4 +185,150 @@ func TestIncrementPC(t *testing.T) {
 			t.Errorf("expected 22 found %v", process.PC)
 		}
 	})
-}
\ No newline at end of file
+}
+func TestResetPC(t *testing.T) {
+	process := PCB{
+		layout:   DefaultConfig().layout(),
+		Start:    10,
+		CodeSize: 6,
+		PC:       19,
+	}
+
+	process.ResetPC()
+
+	if process.PC != 10+PCBSize {
+		t.Errorf("expected %v found %v", 10+PCBSize, process.PC)
+	}
+	if process.RemainingInstructions() != 6 {
+		t.Errorf("expected 6 found %v", process.RemainingInstructions())
+	}
+}
+
+func TestSetPC(t *testing.T) {
+	process := PCB{
+		layout:   DefaultConfig().layout(),
+		Start:    10,
+		CodeSize: 6,
+		PC:       16,
+	}
+
+	// the cases run in order since every one starts from the pc the previous one left
+	tests := []struct {
+		name       string
+		location   int
+		expectedPC int
+		err        error
+	}{
+		{name: "first instruction", location: 0, expectedPC: 16, err: nil},
+		{name: "last instruction", location: 5, expectedPC: 21, err: nil},
+		{name: "end of code", location: 6, expectedPC: 22, err: nil},
+		{name: "past end of code", location: 7, expectedPC: 22, err: ProtectionErr},
+		{name: "negative location", location: -1, expectedPC: 22, err: ProtectionErr},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := process.SetPC(test.location); err != test.err {
+				t.Errorf("expected %v found %v", test.err, err)
+			}
+			if process.PC != test.expectedPC {
+				t.Errorf("expected %v found %v", test.expectedPC, process.PC)
+			}
+		})
+	}
+}
+
+func TestSetDataWordProtection(t *testing.T) {
+	ram := newRAMMemory(memoryWords)
+	process := PCB{
+		layout:   DefaultConfig().layout(),
+		Start:    10,
+		CodeSize: 6,
+		PC:       16,
+		ram:      &ram,
+	}
+
+	if err := process.setDataWord(2, "text"); err != nil {
+		t.Errorf("expected nil found %v", err)
+	}
+	if data, _ := process.getDataWord(2); data != "text" {
+		t.Errorf("expected text found %v", data)
+	}
+
+	if err := process.setDataWord(3, "text"); err != ProtectionErr {
+		t.Errorf("expected %v found %v", ProtectionErr, err)
+	}
+	if ram[process.getVariablesAddress()+3] != "" {
+		t.Errorf("expected memory after the process to be untouched")
+	}
+}
+
+func TestVariable(t *testing.T) {
+	ram := newRAMMemory(memoryWords)
+	process := PCB{
+		layout:   DefaultConfig().layout(),
+		Start:    10,
+		CodeSize: 6,
+		PC:       16,
+		ram:      &ram,
+	}
+
+	if err := process.SetVariable(0, StringOf("42")); err != nil {
+		t.Errorf("expected nil found %v", err)
+	}
+	if err := process.SetVariable(1, IntegerOf(42)); err != nil {
+		t.Errorf("expected nil found %v", err)
+	}
+
+	if value, _ := process.GetVariable(0); value != StringOf("42") {
+		t.Errorf("expected %v found %v", StringOf("42"), value)
+	}
+	if value, _ := process.GetVariable(1); value != IntegerOf(42) {
+		t.Errorf("expected %v found %v", IntegerOf(42), value)
+	}
+
+	if err := process.SetVariable(3, IntegerOf(1)); err != ProtectionErr {
+		t.Errorf("expected %v found %v", ProtectionErr, err)
+	}
+	if _, err := process.GetVariable(-1); err != ProtectionErr {
+		t.Errorf("expected %v found %v", ProtectionErr, err)
+	}
+}
+
+func TestDeclareVariable(t *testing.T) {
+	memoryManager, _ := NewMemoryManager(DefaultConfig())
+	process, _ := memoryManager.AddProcess([]string{"code"})
+
+	for location, name := range []string{"x", "y", "z"} {
+		declared, err := process.DeclareVariable(name)
+		if err != nil || declared != location {
+			t.Errorf("expected %v found %v, %v", location, declared, err)
+		}
+	}
+	if declared, _ := process.DeclareVariable("y"); declared != 1 {
+		t.Errorf("expected 1 found %v", declared)
+	}
+	if _, err := process.DeclareVariable("w"); err != TooManyVariablesErr {
+		t.Errorf("expected %v found %v", TooManyVariablesErr, err)
+	}
+	for _, name := range []string{"", "a=b", "a:b"} {
+		if _, err := process.DeclareVariable(name); err != InvalidVariableNameErr {
+			t.Errorf("expected %v found %v", InvalidVariableNameErr, err)
+		}
+	}
+
+	// the name is kept in the data word with the value
+	process.SetVariable(1, StringOf("a=b:c"))
+	if location, isDeclared := process.FindVariable("y"); !isDeclared || location != 1 {
+		t.Errorf("expected 1 found %v", location)
+	}
+	if word, _ := process.getDataWord(1); word != "y=s:a=b:c" {
+		t.Errorf("expected %q found %q", "y=s:a=b:c", word)
+	}
+
+	// deleting the process frees its symbol table
+	memoryManager.DeleteProcess(process.Id)
+	process, _ = memoryManager.AddProcess([]string{"code"})
+	if _, isDeclared := process.FindVariable("x"); isDeclared {
+		t.Errorf("expected x not to be declared in a new process")
+	}
+}
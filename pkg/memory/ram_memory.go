@@ -10,70 +10,102 @@ var UnableToRetrievePCBErr = errors.New("not able to retrieve PCB from memory")
 
 
 
-// RAMMemory represents a Fixed-sized 40 words RAM memory
-type RAMMemory [memorySize]string
+// RAMMemory represents a RAM memory of a configured number of words addressed from memoryStartAddress
+type RAMMemory []string
 
+func newRAMMemory(words int) RAMMemory {
+	return make(RAMMemory, memoryStartAddress+words)
+}
 
+// endAddress returns the address of the last word
+func (ram *RAMMemory) endAddress() int {
+	return len(*ram) - 1
+}
 
+// holes returns the runs of free words in address order
+func (ram *RAMMemory) holes() []Hole {
+	holes := []Hole{}
+	for i := memoryStartAddress; i <= ram.endAddress(); i++ {
+		if (*ram)[i] != "" {
+			continue
+		}
+		if len(holes) > 0 && holes[len(holes)-1].Start+holes[len(holes)-1].Size == i {
+			holes[len(holes)-1].Size++
+		} else {
+			holes = append(holes, Hole{Start: i, Size: 1})
+		}
+	}
+	return holes
+}
 
 func (ram *RAMMemory) isFree(from int, to int) bool {
-	if from > memoryEndAddress || from < memoryStartAddress || to > memoryEndAddress || to < memoryStartAddress {
+	if from > ram.endAddress() || from < memoryStartAddress || to > ram.endAddress() || to < memoryStartAddress {
 		return false
 	}
 
 	for i := from; i <= to; i++ {
-		if ram[i] != "" {
+		if (*ram)[i] != "" {
 			return false
 		}
 	}
 	return true
 }
 
-func (ram *RAMMemory) allocateProcess(start int, unparsedCode []string,id int) PCB {
-	end := start + getProcessSize(len(unparsedCode)) -1
+func (ram *RAMMemory) allocateProcess(start int, unparsedCode []string,id int, layout layout) PCB {
+	end := start + layout.pcbSize + len(unparsedCode) + layout.variablesSize -1
 
 	pcb := PCB{
 		Id:       id,
 		State:    Ready,
-		PC:       start + PCBSize,
+		PC:       start + layout.pcbSize,
 		Start:    start,
 		End:      end,
 		CodeSize: len(unparsedCode),
+		layout:   layout,
 		ram:ram,
 	}
 
 	// allocate pcb in the first 6 words
-	pcbAddress:=pcb.getPCBAddress()
-	ram[pcbAddress] = fmt.Sprint(pcb.Id)
-	ram[pcbAddress+1] = fmt.Sprint(pcb.State)
-	ram[pcbAddress+2] = fmt.Sprint(pcb.PC)
-	ram[pcbAddress+3] = fmt.Sprint(pcb.Start)
-	ram[pcbAddress+4] = fmt.Sprint(pcb.End)
-	ram[pcbAddress+5] = fmt.Sprint(pcb.CodeSize)
+	ram.writePCB(pcb)
+	// the reserved words after the pcb fields are zeroed so they aren't taken as free
+	for i := PCBSize; i < layout.pcbSize; i++ {
+		(*ram)[pcb.getPCBAddress()+i] = fmt.Sprint(0)
+	}
 
 	// allocate unparsed code
 	unparsedCodeStartAddress := pcb.getUnparsedCodeAddress()
 	for i := 0; i < len(unparsedCode); i++ {
-		ram[unparsedCodeStartAddress+i] = unparsedCode[i]
+		(*ram)[unparsedCodeStartAddress+i] = unparsedCode[i]
 	}
 
-	// allocate variables in the last three words. initial value is zero
+	// allocate variables in the last words. initial value is zero and no variable is declared
 	variablesStartAddress := pcb.getVariablesAddress()
-	ram[variablesStartAddress] = fmt.Sprint(0)
-	ram[variablesStartAddress+1] = fmt.Sprint(0)
-	ram[variablesStartAddress+2] = fmt.Sprint(0)
+	for i := 0; i < layout.variablesSize; i++ {
+		ram.writeValue(variablesStartAddress+i, IntegerOf(0))
+	}
 
 	return pcb
 }
 
-func (ram *RAMMemory) getProcessPCB(startLocation int) (PCB, error) {
+// writePCB stores the fields of the pcb in its first words
+func (ram *RAMMemory) writePCB(pcb PCB) {
+	pcbAddress:=pcb.getPCBAddress()
+	(*ram)[pcbAddress] = fmt.Sprint(pcb.Id)
+	(*ram)[pcbAddress+1] = fmt.Sprint(pcb.State)
+	(*ram)[pcbAddress+2] = fmt.Sprint(pcb.PC)
+	(*ram)[pcbAddress+3] = fmt.Sprint(pcb.Start)
+	(*ram)[pcbAddress+4] = fmt.Sprint(pcb.End)
+	(*ram)[pcbAddress+5] = fmt.Sprint(pcb.CodeSize)
+}
+
+func (ram *RAMMemory) getProcessPCB(startLocation int, layout layout) (PCB, error) {
 
-	id, idErr := strconv.Atoi(ram[startLocation])
-	state:=STATE(ram[startLocation+1])
-	pc, pcErr := strconv.Atoi(ram[startLocation+2])
-	start, startErr := strconv.Atoi(ram[startLocation+3])
-	end, endErr := strconv.Atoi(ram[startLocation+4])
-	codeSize, codeSizeErr := strconv.Atoi(ram[startLocation+5])
+	id, idErr := strconv.Atoi((*ram)[startLocation])
+	state:=STATE((*ram)[startLocation+1])
+	pc, pcErr := strconv.Atoi((*ram)[startLocation+2])
+	start, startErr := strconv.Atoi((*ram)[startLocation+3])
+	end, endErr := strconv.Atoi((*ram)[startLocation+4])
+	codeSize, codeSizeErr := strconv.Atoi((*ram)[startLocation+5])
 
 	if idErr != nil || pcErr != nil || startErr != nil || endErr != nil || codeSizeErr != nil {
 		return PCB{}, UnableToRetrievePCBErr
@@ -86,7 +118,23 @@ func (ram *RAMMemory) getProcessPCB(startLocation int) (PCB, error) {
 		Start:    start,
 		End:      end,
 		CodeSize: codeSize,
+		layout:   layout,
 		ram: ram,
 	}
 	return pcb, nil
 }
+
+// writeVariable stores the name of the variable and its value with its type tag at the given address
+func (ram *RAMMemory) writeVariable(address int, name string, value Value) {
+	(*ram)[address] = encodeVariable(name, value)
+}
+
+// readVariable retrieves the name of the variable and its typed value stored at the given address
+func (ram *RAMMemory) readVariable(address int) (string, Value, error) {
+	return decodeVariable((*ram)[address])
+}
+
+// writeValue stores the value with its type tag at the given address
+func (ram *RAMMemory) writeValue(address int, value Value) {
+	(*ram)[address] = value.encode()
+}
@@ -6,7 +6,7 @@ import (
 )
 
 func TestAllocateProcess(t *testing.T) {
-	var ram RAMMemory
+	ram := newRAMMemory(memoryWords)
 
 	unparsedCode := []string{
 		"assign x 4",
@@ -15,7 +15,7 @@ func TestAllocateProcess(t *testing.T) {
 		"assign x 4",
 	}
 
-	found := ram.allocateProcess(10, unparsedCode,1)
+	found := ram.allocateProcess(10, unparsedCode,1, DefaultConfig().layout())
 
 	expected := PCB{
 		Start:    10,
@@ -44,7 +44,7 @@ func TestAllocateProcess(t *testing.T) {
 		"4",
 	}
 
-	foundPCBInMemory := ram[11:16]
+	foundPCBInMemory := []string(ram[11:16])
 
 	if !reflect.DeepEqual(expectedPCBInMemory, foundPCBInMemory) {
 		t.Errorf("expected %v but found %v", expectedPCBInMemory, foundPCBInMemory)
@@ -53,16 +53,16 @@ func TestAllocateProcess(t *testing.T) {
 
 func TestGetProcessPCB(t *testing.T) {
 	t.Run("normal case successful retrieval of process pcb", func(t *testing.T) {
-		var ram RAMMemory
+		ram := newRAMMemory(memoryWords)
 		unparsedCode := []string{
 			"assign x 4",
 			"print x",
 			"semWait file",
 		}
 
-		pcb := ram.allocateProcess(10, unparsedCode,1)
+		pcb := ram.allocateProcess(10, unparsedCode,1, DefaultConfig().layout())
 
-		found, err := ram.getProcessPCB(10)
+		found, err := ram.getProcessPCB(10, DefaultConfig().layout())
 
 		if err != nil {
 			t.Errorf("expected nil, found %v", err)
@@ -74,16 +74,16 @@ func TestGetProcessPCB(t *testing.T) {
 	})
 
 	t.Run("failed retrieval of process pcb", func(t *testing.T) {
-		var ram RAMMemory
+		ram := newRAMMemory(memoryWords)
 		unparsedCode := []string{
 			"assign x 4",
 			"print x",
 			"semWait file",
 		}
 
-		ram.allocateProcess(10, unparsedCode,2)
+		ram.allocateProcess(10, unparsedCode,2, DefaultConfig().layout())
 
-		_, err := ram.getProcessPCB(12)
+		_, err := ram.getProcessPCB(12, DefaultConfig().layout())
 
 		if err != UnableToRetrievePCBErr {
 			t.Errorf("expected nil, found %v", err)
@@ -0,0 +1,240 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PageRef identifies a page of a process.
+type PageRef struct {
+	Id   int
+	Page int
+}
+
+func (r PageRef) String() string {
+	return fmt.Sprintf("%d:%d", r.Id, r.Page)
+}
+
+// ReplacementPolicy decides which loaded page is evicted when every frame is used.
+type ReplacementPolicy interface {
+	// Load is called when the page is loaded in a frame.
+	Load(page PageRef)
+	// Reference is called every time an instruction accesses the page.
+	Reference(page PageRef)
+	// Remove is called when the page leaves its frame.
+	Remove(page PageRef)
+	// Victim returns the index of the page that is evicted among the candidates.
+	Victim(candidates []PageRef) int
+}
+
+const (
+	FIFOReplacement    = "fifo"
+	LRUReplacement     = "lru"
+	ClockReplacement   = "clock"
+	OptimalReplacement = "optimal"
+)
+
+var (
+	UnknownReplacementErr = errors.New("unknown page replacement policy")
+	InvalidReferenceErr   = errors.New("invalid page reference")
+)
+
+// NewReplacementPolicy creates the replacement policy with the given name,
+// the optimal policy evicts pages knowing the given reference string.
+func NewReplacementPolicy(name string, references []PageRef) (ReplacementPolicy, error) {
+	switch name {
+	case FIFOReplacement:
+		return &FIFO{}, nil
+	case LRUReplacement:
+		return &LRU{lastUse: map[PageRef]int{}}, nil
+	case ClockReplacement:
+		return &Clock{referenced: map[PageRef]bool{}}, nil
+	case OptimalReplacement:
+		return NewOptimal(references), nil
+	}
+	return nil, UnknownReplacementErr
+}
+
+// ParseReferences reads a reference string written one `id:page` reference per line, blank lines are skipped.
+func ParseReferences(lines []string) ([]PageRef, error) {
+	references := []PageRef{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		idText, pageText, isPresent := strings.Cut(line, ":")
+		id, idErr := strconv.Atoi(idText)
+		page, pageErr := strconv.Atoi(pageText)
+		if !isPresent || idErr != nil || pageErr != nil {
+			return nil, InvalidReferenceErr
+		}
+		references = append(references, PageRef{Id: id, Page: page})
+	}
+	return references, nil
+}
+
+// FIFO evicts the page that was loaded first.
+type FIFO struct {
+	order []PageRef
+}
+
+func (p *FIFO) Load(page PageRef) {
+	p.order = append(p.order, page)
+}
+
+func (p *FIFO) Reference(page PageRef) {}
+
+func (p *FIFO) Remove(page PageRef) {
+	p.order = removePage(p.order, page)
+}
+
+func (p *FIFO) Victim(candidates []PageRef) int {
+	for _, page := range p.order {
+		if index := indexOfPage(candidates, page); index >= 0 {
+			return index
+		}
+	}
+	return 0
+}
+
+// LRU evicts the page that wasn't referenced for the longest time.
+type LRU struct {
+	clock   int
+	lastUse map[PageRef]int
+}
+
+func (p *LRU) Load(page PageRef) {
+	p.Reference(page)
+}
+
+func (p *LRU) Reference(page PageRef) {
+	p.clock++
+	p.lastUse[page] = p.clock
+}
+
+func (p *LRU) Remove(page PageRef) {
+	delete(p.lastUse, page)
+}
+
+func (p *LRU) Victim(candidates []PageRef) int {
+	victim := 0
+	for index, page := range candidates {
+		if p.lastUse[page] < p.lastUse[candidates[victim]] {
+			victim = index
+		}
+	}
+	return victim
+}
+
+// Clock gives every referenced page a second chance, the hand goes around the loaded pages clearing
+// their reference bits and evicts the first page whose bit is already clear.
+type Clock struct {
+	ring       []PageRef
+	hand       int
+	referenced map[PageRef]bool
+}
+
+func (p *Clock) Load(page PageRef) {
+	// the new page takes the place right behind the hand so it's the last one the hand reaches
+	p.ring = append(p.ring[:p.hand], append([]PageRef{page}, p.ring[p.hand:]...)...)
+	p.hand = (p.hand + 1) % len(p.ring)
+}
+
+func (p *Clock) Reference(page PageRef) {
+	p.referenced[page] = true
+}
+
+func (p *Clock) Remove(page PageRef) {
+	index := indexOfPage(p.ring, page)
+	if index < 0 {
+		return
+	}
+	p.ring = removePage(p.ring, page)
+	delete(p.referenced, page)
+	if index < p.hand {
+		p.hand--
+	}
+	if p.hand >= len(p.ring) {
+		p.hand = 0
+	}
+}
+
+func (p *Clock) Victim(candidates []PageRef) int {
+	// every candidate has its bit cleared in the first round, so a victim is found in the second one
+	for step := 0; step < 2*len(p.ring); step++ {
+		page := p.ring[p.hand]
+		index := indexOfPage(candidates, page)
+		if index >= 0 && !p.referenced[page] {
+			return index
+		}
+		if index >= 0 {
+			p.referenced[page] = false
+		}
+		p.hand = (p.hand + 1) % len(p.ring)
+	}
+	return 0
+}
+
+// Optimal evicts the page that is referenced again the latest, it needs the whole reference string in advance
+// so it's used to replay a reference string recorded with another policy.
+type Optimal struct {
+	references []PageRef
+	position   int
+}
+
+// NewOptimal creates the optimal policy for the given reference string.
+func NewOptimal(references []PageRef) *Optimal {
+	return &Optimal{references: references}
+}
+
+func (p *Optimal) Load(page PageRef) {}
+
+func (p *Optimal) Reference(page PageRef) {
+	p.position++
+}
+
+func (p *Optimal) Remove(page PageRef) {}
+
+func (p *Optimal) Victim(candidates []PageRef) int {
+	victim, victimNextUse := 0, -1
+	for index, page := range candidates {
+		nextUse := p.nextUse(page)
+		if nextUse < 0 {
+			// the page is never referenced again
+			return index
+		}
+		if nextUse > victimNextUse {
+			victim, victimNextUse = index, nextUse
+		}
+	}
+	return victim
+}
+
+// nextUse returns the position of the next reference to the page or -1 if it isn't referenced again
+func (p *Optimal) nextUse(page PageRef) int {
+	for position := p.position; position < len(p.references); position++ {
+		if p.references[position] == page {
+			return position
+		}
+	}
+	return -1
+}
+
+func indexOfPage(pages []PageRef, page PageRef) int {
+	for index, candidate := range pages {
+		if candidate == page {
+			return index
+		}
+	}
+	return -1
+}
+
+func removePage(pages []PageRef, page PageRef) []PageRef {
+	if index := indexOfPage(pages, page); index >= 0 {
+		return append(pages[:index], pages[index+1:]...)
+	}
+	return pages
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+)
+
+// textbookReferences is the reference string of the classic page replacement exercise
+var textbookReferences = func() []PageRef {
+	pages := []int{7, 0, 1, 2, 0, 3, 0, 4, 2, 3, 0, 3, 2, 1, 2, 0, 1, 7, 0, 1}
+	references := make([]PageRef, len(pages))
+	for index, page := range pages {
+		references[index] = PageRef{Id: 1, Page: page}
+	}
+	return references
+}()
+
+// countFaults replays the references with the given number of frames and returns the number of page faults
+func countFaults(policy ReplacementPolicy, frames int, references []PageRef) int {
+	loaded := []PageRef{}
+	faults := 0
+	for _, reference := range references {
+		if indexOfPage(loaded, reference) < 0 {
+			faults++
+			if len(loaded) == frames {
+				victim := loaded[policy.Victim(loaded)]
+				loaded = removePage(loaded, victim)
+				policy.Remove(victim)
+			}
+			loaded = append(loaded, reference)
+			policy.Load(reference)
+		}
+		policy.Reference(reference)
+	}
+	return faults
+}
+
+func TestReplacementPolicies(t *testing.T) {
+	tests := map[string]struct {
+		policy   string
+		expected int
+	}{
+		"fifo":    {policy: FIFOReplacement, expected: 15},
+		"lru":     {policy: LRUReplacement, expected: 12},
+		"clock":   {policy: ClockReplacement, expected: 14},
+		"optimal": {policy: OptimalReplacement, expected: 9},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			policy, err := NewReplacementPolicy(test.policy, textbookReferences)
+			if err != nil {
+				t.Fatalf("expected nil, found %v", err)
+			}
+			if found := countFaults(policy, 3, textbookReferences); found != test.expected {
+				t.Errorf("expected %v faults, found %v", test.expected, found)
+			}
+		})
+	}
+
+	if _, err := NewReplacementPolicy("random", nil); err != UnknownReplacementErr {
+		t.Errorf("expected %v, found %v", UnknownReplacementErr, err)
+	}
+}
+
+func TestClockGivesSecondChance(t *testing.T) {
+	policy, _ := NewReplacementPolicy(ClockReplacement, nil)
+	pages := []PageRef{{Id: 1, Page: 0}, {Id: 1, Page: 1}, {Id: 1, Page: 2}}
+	for _, page := range pages {
+		policy.Load(page)
+	}
+	policy.Reference(pages[0])
+
+	if found := policy.Victim(pages); found != 1 {
+		t.Errorf("expected 1, found %v", found)
+	}
+}
+
+func TestParseReferences(t *testing.T) {
+	found, err := ParseReferences([]string{"1:0", " 2:3 ", ""})
+	if err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	expected := []PageRef{{Id: 1, Page: 0}, {Id: 2, Page: 3}}
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected %v, found %v", expected, found)
+	}
+	if found[1].String() != "2:3" {
+		t.Errorf("expected 2:3, found %v", found[1].String())
+	}
+
+	for _, line := range []string{"1", "a:1", "1:b"} {
+		if _, err := ParseReferences([]string{line}); err != InvalidReferenceErr {
+			t.Errorf("%q: expected %v, found %v", line, InvalidReferenceErr, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ValueType is the type tag of a value stored in a data word.
+type ValueType string
+
+const (
+	IntegerValue ValueType = "i"
+	StringValue  ValueType = "s"
+)
+
+const (
+	valueTagSeparator     = ":"
+	variableNameSeparator = "="
+)
+
+var (
+	InvalidValueErr        = errors.New("data word doesn't hold a typed value")
+	InvalidVariableNameErr = errors.New("variable name can't be empty or contain '=' or ':'")
+)
+
+// Value represents typed data stored in a data word.
+type Value struct {
+	Type ValueType
+	Data string
+}
+
+// IntegerOf creates an integer value.
+func IntegerOf(number int) Value {
+	return Value{Type: IntegerValue, Data: strconv.Itoa(number)}
+}
+
+// StringOf creates a string value.
+func StringOf(text string) Value {
+	return Value{Type: StringValue, Data: text}
+}
+
+// encode returns the memory word of the value prefixed with its type tag.
+func (v Value) encode() string {
+	return string(v.Type) + valueTagSeparator + v.Data
+}
+
+// decodeValue parses a memory word written by encode.
+func decodeValue(word string) (Value, error) {
+	tag, data, isTagged := strings.Cut(word, valueTagSeparator)
+	if !isTagged {
+		return Value{}, InvalidValueErr
+	}
+	switch ValueType(tag) {
+	case IntegerValue:
+		if _, err := strconv.Atoi(data); err != nil {
+			return Value{}, InvalidValueErr
+		}
+		return Value{Type: IntegerValue, Data: data}, nil
+	case StringValue:
+		return Value{Type: StringValue, Data: data}, nil
+	}
+	return Value{}, InvalidValueErr
+}
+
+// encodeVariable returns the memory word of a variable, its name followed by its tagged value.
+// A data word that isn't declared as a variable holds only the tagged value.
+func encodeVariable(name string, value Value) string {
+	if name == "" {
+		return value.encode()
+	}
+	return name + variableNameSeparator + value.encode()
+}
+
+// decodeVariable parses a memory word written by encodeVariable.
+func decodeVariable(word string) (string, Value, error) {
+	name, encodedValue, isNamed := strings.Cut(word, variableNameSeparator)
+	if !isNamed || strings.Contains(name, valueTagSeparator) {
+		// the separator belongs to the data of an undeclared word
+		name, encodedValue = "", word
+	}
+	value, err := decodeValue(encodedValue)
+	return name, value, err
+}
+
+func isValidVariableName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, variableNameSeparator+valueTagSeparator)
+}
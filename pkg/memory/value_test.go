@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"testing"
+)
+
+func TestEncodeValue(t *testing.T) {
+	tests := map[string]struct {
+		value    Value
+		expected string
+	}{
+		"integer value":         {value: IntegerOf(42), expected: "i:42"},
+		"string value":          {value: StringOf("hello"), expected: "s:hello"},
+		"numeric string value":  {value: StringOf("42"), expected: "s:42"},
+		"string with separator": {value: StringOf("a:b"), expected: "s:a:b"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			found := test.value.encode()
+			if found != test.expected {
+				t.Errorf("expected %v found %v", test.expected, found)
+			}
+
+			decoded, err := decodeValue(found)
+			if err != nil {
+				t.Errorf("expected nil found %v", err)
+			}
+			if decoded != test.value {
+				t.Errorf("expected %v found %v", test.value, decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidValue(t *testing.T) {
+	for _, word := range []string{"", "42", "i:text", "x:42"} {
+		if _, err := decodeValue(word); err != InvalidValueErr {
+			t.Errorf("%q: expected %v found %v", word, InvalidValueErr, err)
+		}
+	}
+}
+
+func TestDecodeVariable(t *testing.T) {
+	tests := map[string]struct {
+		word  string
+		name  string
+		value Value
+	}{
+		"declared variable":        {word: "x=i:5", name: "x", value: IntegerOf(5)},
+		"declared string variable": {word: "s=s:a=b", name: "s", value: StringOf("a=b")},
+		"undeclared word":          {word: "i:0", name: "", value: IntegerOf(0)},
+		"undeclared string word":   {word: "s:a=b", name: "", value: StringOf("a=b")},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			variableName, value, err := decodeVariable(test.word)
+			if err != nil {
+				t.Errorf("expected nil found %v", err)
+			}
+			if variableName != test.name || value != test.value {
+				t.Errorf("expected %v %v found %v %v", test.name, test.value, variableName, value)
+			}
+			if word := encodeVariable(test.name, test.value); word != test.word {
+				t.Errorf("expected %v found %v", test.word, word)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package mutex
+
+import "errors"
+
+var (
+	// ErrClaimExceedsTotal is returned when a process claims more instances than a resource has.
+	ErrClaimExceedsTotal = errors.New("claim exceeds the total instances of the resource")
+	// ErrClaimBelowAllocation is returned when a process claims less than it already holds.
+	ErrClaimBelowAllocation = errors.New("claim is less than the allocated instances of the resource")
+	// ErrRequestExceedsClaim is returned when a process requests more instances than it claimed.
+	ErrRequestExceedsClaim = errors.New("request exceeds the claim of the process")
+)
+
+// Request is a resource request of a blocked process.
+type Request struct {
+	Process  Process
+	Resource string
+}
+
+// banker grants resource instances only when the resulting state is safe per the Banker's algorithm.
+// Resources declared with SemInit have as many instances as their initial count, any other resource has one.
+type banker struct {
+	total       map[string]int
+	claims      map[Process]map[string]int
+	allocations map[Process]map[string]int
+	pending     []Request
+}
+
+func newBanker() *banker {
+	return &banker{
+		total:       map[string]int{},
+		claims:      map[Process]map[string]int{},
+		allocations: map[Process]map[string]int{},
+		pending:     []Request{},
+	}
+}
+
+func (b *banker) declare(resource string, instances int) {
+	b.total[resource] = instances
+}
+
+func (b *banker) instances(resource string) int {
+	if total, isDeclared := b.total[resource]; isDeclared {
+		return total
+	}
+	return 1
+}
+
+func (b *banker) available(resource string) int {
+	available := b.instances(resource)
+	for _, allocation := range b.allocations {
+		available -= allocation[resource]
+	}
+	return available
+}
+
+func (b *banker) claim(process Process, resource string, instances int) error {
+	if instances > b.instances(resource) {
+		return ErrClaimExceedsTotal
+	}
+	if instances < b.allocations[process][resource] {
+		return ErrClaimBelowAllocation
+	}
+	if b.claims[process] == nil {
+		b.claims[process] = map[string]int{}
+	}
+	b.claims[process][resource] = instances
+	return nil
+}
+
+// checkClaim returns ErrRequestExceedsClaim if one more instance of the resource exceeds the claim of the process.
+func (b *banker) checkClaim(resource string, process Process) error {
+	if b.allocations[process][resource] >= b.claims[process][resource] {
+		return ErrRequestExceedsClaim
+	}
+	return nil
+}
+
+// wait grants one instance of the resource if it is available and the resulting state is safe,
+// otherwise the request waits in FIFO order. A request beyond the claim is never granted nor queued.
+func (b *banker) wait(resource string, process Process) bool {
+	if b.checkClaim(resource, process) != nil {
+		return false
+	}
+	if b.grant(resource, process) {
+		return true
+	}
+	b.pending = append(b.pending, Request{Process: process, Resource: resource})
+	return false
+}
+
+// signal releases one instance of the resource held by the process.
+func (b *banker) signal(resource string, process Process) bool {
+	if b.allocations[process][resource] == 0 {
+		return false
+	}
+	b.allocations[process][resource]--
+	return true
+}
+
+// grantSafeRequests grants the pending requests that became safe in FIFO order.
+func (b *banker) grantSafeRequests() []Process {
+	granted := []Process{}
+	remaining := []Request{}
+	for _, pending := range b.pending {
+		if b.grant(pending.Resource, pending.Process) {
+			granted = append(granted, pending.Process)
+		} else {
+			remaining = append(remaining, pending)
+		}
+	}
+	b.pending = remaining
+	return granted
+}
+
+// releaseAll releases every instance held by the process and forgets its claims and pending requests.
+func (b *banker) releaseAll(process Process) {
+	delete(b.allocations, process)
+	delete(b.claims, process)
+	remaining := []Request{}
+	for _, pending := range b.pending {
+		if pending.Process != process {
+			remaining = append(remaining, pending)
+		}
+	}
+	b.pending = remaining
+}
+
+func (b *banker) holders(resource string) []Process {
+	holders := []Process{}
+	for process, allocation := range b.allocations {
+		if allocation[resource] > 0 {
+			holders = append(holders, process)
+		}
+	}
+	return holders
+}
+
+func (b *banker) waiters(resource string) []Process {
+	waiters := []Process{}
+	for _, pending := range b.pending {
+		if pending.Resource == resource {
+			waiters = append(waiters, pending.Process)
+		}
+	}
+	return waiters
+}
+
+// grant allocates one instance of the resource to the process if the resulting state is safe.
+func (b *banker) grant(resource string, process Process) bool {
+	if b.available(resource) == 0 {
+		return false
+	}
+	if b.allocations[process] == nil {
+		b.allocations[process] = map[string]int{}
+	}
+
+	b.allocations[process][resource]++
+	if b.isSafe() {
+		return true
+	}
+	b.allocations[process][resource]--
+	return false
+}
+
+// isSafe reports whether every process can finish in some order, given that each one
+// may request up to its claim before releasing everything it holds.
+func (b *banker) isSafe() bool {
+	work := map[string]int{}
+	for resource := range b.resources() {
+		work[resource] = b.available(resource)
+	}
+
+	finished := map[Process]bool{}
+	for progress := true; progress; {
+		progress = false
+		for process := range b.processes() {
+			if finished[process] || !b.canFinish(process, work) {
+				continue
+			}
+			for resource, allocation := range b.allocations[process] {
+				work[resource] += allocation
+			}
+			finished[process] = true
+			progress = true
+		}
+	}
+	return len(finished) == len(b.processes())
+}
+
+func (b *banker) canFinish(process Process, work map[string]int) bool {
+	for resource, claim := range b.claims[process] {
+		if claim-b.allocations[process][resource] > work[resource] {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *banker) processes() map[Process]bool {
+	processes := map[Process]bool{}
+	for process := range b.claims {
+		processes[process] = true
+	}
+	for process := range b.allocations {
+		processes[process] = true
+	}
+	return processes
+}
+
+func (b *banker) resources() map[string]bool {
+	resources := map[string]bool{}
+	for resource := range b.total {
+		resources[resource] = true
+	}
+	for _, claim := range b.claims {
+		for resource := range claim {
+			resources[resource] = true
+		}
+	}
+	return resources
+}
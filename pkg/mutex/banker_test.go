@@ -0,0 +1,113 @@
+package mutex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAvoidance(t *testing.T) {
+	t.Run("test unsafe request blocks the caller", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.EnableAvoidance()
+		const firstProcess Process = 1
+		const secondProcess Process = 2
+		for _, process := range []Process{firstProcess, secondProcess} {
+			mutex.Claim("file", process, 1)
+			mutex.Claim("userInput", process, 1)
+		}
+
+		if !mutex.SemWait("file", firstProcess) {
+			t.Fatalf("Expected process %d to acquire resource %q\n", firstProcess, "file")
+		}
+		// granting userInput would let each process hold what the other one needs
+		if mutex.SemWait("userInput", secondProcess) {
+			t.Fatalf("Expected process %d to be blocked\n", secondProcess)
+		}
+		if _, isLocked := mutex.Owner("userInput"); isLocked {
+			t.Fatalf("Expected resource %q to stay free\n", "userInput")
+		}
+		expected := []Process{secondProcess}
+		if waiters := mutex.Waiters("userInput"); !reflect.DeepEqual(expected, waiters) {
+			t.Fatalf("Expected waiters %v, found %v\n", expected, waiters)
+		}
+
+		if !mutex.SemWait("userInput", firstProcess) {
+			t.Fatalf("Expected process %d to acquire resource %q\n", firstProcess, "userInput")
+		}
+		mutex.SemSignal("userInput", firstProcess)
+		mutex.SemSignal("file", firstProcess)
+
+		if granted := mutex.GrantSafeRequests(); !reflect.DeepEqual(expected, granted) {
+			t.Fatalf("Expected granted processes %v, found %v\n", expected, granted)
+		}
+		if owner, _ := mutex.Owner("userInput"); owner != secondProcess {
+			t.Fatalf("Expected process %d, found %d\n", secondProcess, owner)
+		}
+	})
+
+	t.Run("test multiple instances of a declared semaphore", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.EnableAvoidance()
+		mutex.SemInit("printer", 3)
+		mutex.Claim("printer", 1, 2)
+		mutex.Claim("printer", 2, 2)
+
+		if !mutex.SemWait("printer", 1) || !mutex.SemWait("printer", 2) {
+			t.Fatalf("Expected both processes to acquire an instance\n")
+		}
+		if !mutex.SemWait("printer", 1) {
+			t.Fatalf("Expected process %d to acquire its last instance\n", 1)
+		}
+		if mutex.SemWait("printer", 2) {
+			t.Fatalf("Expected process %d to be blocked\n", 2)
+		}
+	})
+
+	t.Run("test invalid claims", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.EnableAvoidance()
+		if err := mutex.Claim("file", 1, 2); err != ErrClaimExceedsTotal {
+			t.Fatalf("Expected %v, found %v\n", ErrClaimExceedsTotal, err)
+		}
+		mutex.SemInit("printer", 2)
+		mutex.Claim("printer", 1, 2)
+		mutex.SemWait("printer", 1)
+		mutex.SemWait("printer", 1)
+		if err := mutex.Claim("printer", 1, 1); err != ErrClaimBelowAllocation {
+			t.Fatalf("Expected %v, found %v\n", ErrClaimBelowAllocation, err)
+		}
+	})
+
+	t.Run("test only holders release resources", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.EnableAvoidance()
+		mutex.Claim("file", 1, 1)
+		mutex.SemWait("file", 1)
+		if _, released := mutex.SemSignal("file", 2); released {
+			t.Fatalf("Expected process %d not to release resource %q\n", 2, "file")
+		}
+	})
+	t.Run("test request above the claim is rejected", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.EnableAvoidance()
+		mutex.SemInit("printer", 2)
+		mutex.Claim("printer", 1, 1)
+
+		if err := mutex.CheckClaim("printer", 1); err != nil {
+			t.Fatalf("Expected nil, found %v\n", err)
+		}
+		mutex.SemWait("printer", 1)
+		if err := mutex.CheckClaim("printer", 1); err != ErrRequestExceedsClaim {
+			t.Fatalf("Expected %v, found %v\n", ErrRequestExceedsClaim, err)
+		}
+		if mutex.SemWait("printer", 1) {
+			t.Fatalf("Expected process %d not to acquire an instance beyond its claim\n", 1)
+		}
+		if pending := mutex.PendingRequests(); len(pending) != 0 {
+			t.Fatalf("Expected no pending requests, found %v\n", pending)
+		}
+		if err := mutex.CheckClaim("file", 2); err != ErrRequestExceedsClaim {
+			t.Fatalf("Expected %v, found %v\n", ErrRequestExceedsClaim, err)
+		}
+	})
+}
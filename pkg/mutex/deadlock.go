@@ -0,0 +1,70 @@
+package mutex
+
+import "sort"
+
+// Deadlock describes a cycle of processes where each one waits for a resource owned by the next.
+// Resources[i] is the resource that Processes[i] is waiting for.
+type Deadlock struct {
+	Processes []Process
+	Resources []string
+}
+
+// waitEdge is an edge of the wait-for graph from a blocked process to the owner of its resource.
+type waitEdge struct {
+	resource string
+	owner    Process
+}
+
+// DetectDeadlock finds every cycle in the wait-for graph built from the owners and blocked processes of each resource.
+// Counting semaphores have no owner, so processes blocked on them are never part of a deadlock.
+func (m *Mutex) DetectDeadlock() []Deadlock {
+	waitsFor := m.waitForGraph()
+
+	// visit processes in a fixed order so the reported cycles are deterministic
+	processes := make([]Process, 0, len(waitsFor))
+	for process := range waitsFor {
+		processes = append(processes, process)
+	}
+	sort.Slice(processes, func(i, j int) bool { return processes[i] < processes[j] })
+
+	deadlocks := []Deadlock{}
+	visited := map[Process]bool{}
+	for _, start := range processes {
+		// every blocked process waits for a single resource, so following the edges gives a single path
+		path := []Process{}
+		onPath := map[Process]int{}
+		process := start
+		for !visited[process] {
+			edge, isWaiting := waitsFor[process]
+			if !isWaiting {
+				break
+			}
+			visited[process] = true
+			onPath[process] = len(path)
+			path = append(path, process)
+			process = edge.owner
+		}
+
+		cycleStart, isCycle := onPath[process]
+		if !isCycle {
+			continue
+		}
+		deadlock := Deadlock{}
+		for _, cycleProcess := range path[cycleStart:] {
+			deadlock.Processes = append(deadlock.Processes, cycleProcess)
+			deadlock.Resources = append(deadlock.Resources, waitsFor[cycleProcess].resource)
+		}
+		deadlocks = append(deadlocks, deadlock)
+	}
+	return deadlocks
+}
+
+func (m *Mutex) waitForGraph() map[Process]waitEdge {
+	waitsFor := map[Process]waitEdge{}
+	for name, resource := range m.resources {
+		for _, blockedProcess := range resource.blockedProcesses {
+			waitsFor[blockedProcess] = waitEdge{resource: name, owner: resource.ownerProcess}
+		}
+	}
+	return waitsFor
+}
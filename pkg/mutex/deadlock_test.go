@@ -0,0 +1,48 @@
+package mutex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectDeadlock(t *testing.T) {
+	t.Run("test no deadlock when waiting for a running process", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.SemWait("file", 1)
+		mutex.SemWait("file", 2)
+
+		if deadlocks := mutex.DetectDeadlock(); len(deadlocks) != 0 {
+			t.Fatalf("Expected no deadlocks, found %v\n", deadlocks)
+		}
+	})
+
+	t.Run("test two processes waiting for each other", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.SemWait("file", 1)
+		mutex.SemWait("userInput", 2)
+		mutex.SemWait("userInput", 1)
+		mutex.SemWait("file", 2)
+
+		expected := []Deadlock{{Processes: []Process{1, 2}, Resources: []string{"userInput", "file"}}}
+		if deadlocks := mutex.DetectDeadlock(); !reflect.DeepEqual(expected, deadlocks) {
+			t.Fatalf("Expected %v, found %v\n", expected, deadlocks)
+		}
+	})
+
+	t.Run("test cycle reached from a process outside of it", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.SemWait("a", 2)
+		mutex.SemWait("b", 3)
+		mutex.SemWait("c", 4)
+		mutex.SemWait("b", 2)
+		mutex.SemWait("a", 3)
+		// process 1 waits for the deadlocked process 4 which waits for the cycle
+		mutex.SemWait("c", 1)
+		mutex.SemWait("a", 4)
+
+		expected := []Deadlock{{Processes: []Process{2, 3}, Resources: []string{"b", "a"}}}
+		if deadlocks := mutex.DetectDeadlock(); !reflect.DeepEqual(expected, deadlocks) {
+			t.Fatalf("Expected %v, found %v\n", expected, deadlocks)
+		}
+	})
+}
@@ -1,9 +1,17 @@
 // Package mutex provides a simple implementation of a mutex using semaphores.
 package mutex
 
+import (
+	"errors"
+	"sort"
+)
+
 // Process represents a process identifier.
 type Process int
 
+// NoProcess is returned when no process is affected by an operation.
+const NoProcess Process = 0
+
 // Resource represents a resource with an owner process and a list of blocked processes.
 type Resource struct {
 	ownerProcess     Process
@@ -11,19 +19,102 @@ type Resource struct {
 }
 
 // Mutex represents a mutex that manages resources and provides mutual exclusion.
+// Resources declared with SemInit are counting semaphores, any other resource is a binary lock.
+// In avoidance mode every request is granted by the Banker's algorithm instead.
 type Mutex struct {
-	resources map[string]Resource
+	resources  map[string]Resource
+	semaphores map[string]*Semaphore
+	banker     *banker
 }
 
+var (
+	// ErrNegativeCount is returned when a semaphore is declared with a negative count.
+	ErrNegativeCount = errors.New("semaphore count can't be negative")
+	// ErrResourceExists is returned when a semaphore is declared with the name of an existing resource.
+	ErrResourceExists = errors.New("resource is already declared")
+)
+
 // NewMutex creates a new Mutex instance.
 func NewMutex() Mutex {
-	return Mutex{resources: map[string]Resource{}}
+	return Mutex{resources: map[string]Resource{}, semaphores: map[string]*Semaphore{}}
+}
+
+// EnableAvoidance switches the mutex to deadlock avoidance mode, where a request is granted only if
+// the resulting state is safe per the Banker's algorithm and unsafe requests block the caller.
+// Resources can only be released by the processes holding them in this mode.
+// It must be called before any resource is used.
+func (m *Mutex) EnableAvoidance() {
+	m.banker = newBanker()
+}
+
+// Claim declares the maximum number of instances of the specified resource the process may hold.
+// Claims are only used in avoidance mode.
+func (m *Mutex) Claim(targetResource string, process Process, instances int) error {
+	if m.banker == nil {
+		return nil
+	}
+	return m.banker.claim(process, targetResource, instances)
+}
+
+// CheckClaim returns ErrRequestExceedsClaim in avoidance mode if the process requesting one more instance
+// of the specified resource would hold more than it claimed, such a request is never granted by SemWait.
+func (m *Mutex) CheckClaim(targetResource string, process Process) error {
+	if m.banker == nil {
+		return nil
+	}
+	return m.banker.checkClaim(targetResource, process)
+}
+
+// PendingRequests returns the requests waiting for the Banker's algorithm to grant them in FIFO order.
+func (m *Mutex) PendingRequests() []Request {
+	if m.banker == nil {
+		return nil
+	}
+	pending := make([]Request, len(m.banker.pending))
+	copy(pending, m.banker.pending)
+	return pending
+}
+
+// GrantSafeRequests grants the blocked requests that became safe after a release in avoidance mode.
+// Returns the processes whose requests were granted in FIFO order.
+func (m *Mutex) GrantSafeRequests() []Process {
+	if m.banker == nil {
+		return nil
+	}
+	return m.banker.grantSafeRequests()
+}
+
+// SemInit declares the specified resource as a counting semaphore with the given initial count.
+// Declaring it again with the same initial count does nothing, so every program sharing the semaphore can declare it.
+func (m *Mutex) SemInit(targetResource string, count int) error {
+	if count < 0 {
+		return ErrNegativeCount
+	}
+	_, isLocked := m.resources[targetResource]
+	semaphore, isDeclared := m.semaphores[targetResource]
+	if isDeclared && !isLocked && semaphore.initialCount == count {
+		return nil
+	}
+	if isLocked || isDeclared {
+		return ErrResourceExists
+	}
+	m.semaphores[targetResource] = NewSemaphore(count)
+	if m.banker != nil {
+		m.banker.declare(targetResource, count)
+	}
+	return nil
 }
 
 // SemWait acquires a lock on the specified resource.
 // If the resource is already locked, the calling process is added to the list of blocked processes.
 // Returns true if the lock is acquired, false otherwise.
 func (m *Mutex) SemWait(targetResource string, process Process) bool {
+	if m.banker != nil {
+		return m.banker.wait(targetResource, process)
+	}
+	if semaphore, isSemaphore := m.semaphores[targetResource]; isSemaphore {
+		return semaphore.Wait(process)
+	}
 	// Lock
 	resource, isPresent := m.resources[targetResource]
 	if !isPresent {
@@ -31,9 +122,6 @@ func (m *Mutex) SemWait(targetResource string, process Process) bool {
 		m.resources[targetResource] = Resource{ownerProcess: process, blockedProcesses: []Process{}}
 		return true
 	} else {
-		// TODO:
-		// Notify os to add to blocked queue
-
 		resource.blockedProcesses = append(resource.blockedProcesses, process)
 		m.resources[targetResource] = resource
 		return false
@@ -41,19 +129,98 @@ func (m *Mutex) SemWait(targetResource string, process Process) bool {
 }
 
 // SemSignal releases the lock on the specified resource.
-// If the calling process is the owner of the resource, it releases the lock and updates the state of blocked processes.
+// If the calling process is the owner of the resource, the ownership is handed to the first blocked process
+// in FIFO order and that process is returned, NoProcess is returned when there are no blocked processes.
+// Counting semaphores have no owner and can be signaled by any process.
+// In avoidance mode no process is woken here, the waiting requests are granted by GrantSafeRequests.
 // Returns true if the lock is released, false otherwise.
-func (m *Mutex) SemSignal(targetResource string, process Process) bool {
+func (m *Mutex) SemSignal(targetResource string, process Process) (Process, bool) {
+	if m.banker != nil {
+		return NoProcess, m.banker.signal(targetResource, process)
+	}
+	if semaphore, isSemaphore := m.semaphores[targetResource]; isSemaphore {
+		return semaphore.Signal(), true
+	}
 	// Release
 	resource, isPresent := m.resources[targetResource]
-	if isPresent && resource.ownerProcess == process {
-		for range resource.blockedProcesses {
-			// Update Process State from block to ready
-		}
+	if !isPresent || resource.ownerProcess != process {
+		return NoProcess, false
+	}
+	if len(resource.blockedProcesses) == 0 {
 		// Remove Used Resource
 		delete(m.resources, targetResource)
-		return true
-	} else {
-		return false
+		return NoProcess, true
+	}
+	// Hand the resource to the first blocked process
+	resource.ownerProcess = resource.blockedProcesses[0]
+	resource.blockedProcesses = resource.blockedProcesses[1:]
+	m.resources[targetResource] = resource
+	return resource.ownerProcess, true
+}
+
+// ReleaseAll releases every resource owned by the given process and removes it from every wait queue.
+// Returns the processes that acquired a released resource, ordered by the names of the resources.
+func (m *Mutex) ReleaseAll(process Process) []Process {
+	if m.banker != nil {
+		m.banker.releaseAll(process)
+		return m.banker.grantSafeRequests()
+	}
+	wokenProcesses := []Process{}
+	for _, semaphore := range m.semaphores {
+		semaphore.remove(process)
+	}
+	// the resources are visited in order so the woken processes are queued the same way on every run
+	names := make([]string, 0, len(m.resources))
+	for name := range m.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resource := m.resources[name]
+		for idx, blockedProcess := range resource.blockedProcesses {
+			if blockedProcess == process {
+				resource.blockedProcesses = append(resource.blockedProcesses[:idx:idx], resource.blockedProcesses[idx+1:]...)
+				m.resources[name] = resource
+				break
+			}
+		}
+		if resource.ownerProcess == process {
+			if nextProcess, _ := m.SemSignal(name, process); nextProcess != NoProcess {
+				wokenProcesses = append(wokenProcesses, nextProcess)
+			}
+		}
+	}
+	return wokenProcesses
+}
+
+// Owner returns the process that currently holds the specified resource.
+// Returns false if the resource is not locked.
+// In avoidance mode it returns one of the processes holding an instance of the resource.
+func (m *Mutex) Owner(targetResource string) (Process, bool) {
+	if m.banker != nil {
+		holders := m.banker.holders(targetResource)
+		if len(holders) == 0 {
+			return NoProcess, false
+		}
+		return holders[0], true
+	}
+	resource, isPresent := m.resources[targetResource]
+	if !isPresent {
+		return NoProcess, false
+	}
+	return resource.ownerProcess, true
+}
+
+// Waiters returns the processes blocked on the specified resource in the order they will acquire it.
+func (m *Mutex) Waiters(targetResource string) []Process {
+	if m.banker != nil {
+		return m.banker.waiters(targetResource)
+	}
+	if semaphore, isSemaphore := m.semaphores[targetResource]; isSemaphore {
+		return semaphore.Waiters()
 	}
+	resource := m.resources[targetResource]
+	waiters := make([]Process, len(resource.blockedProcesses))
+	copy(waiters, resource.blockedProcesses)
+	return waiters
 }
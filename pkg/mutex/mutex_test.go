@@ -1,6 +1,7 @@
 package mutex
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -72,7 +73,7 @@ func TestMutex(t *testing.T) {
 		targetResource := "userInput"
 		mutex := NewMutex()
 		mutex.SemWait(targetResource, firstProcess)
-		success := mutex.SemSignal(targetResource, firstProcess)
+		_, success := mutex.SemSignal(targetResource, firstProcess)
 		if !success {
 			t.Fatalf("Failed to release the lock for resource %q to process %d\n", targetResource, firstProcess)
 		}
@@ -83,7 +84,7 @@ func TestMutex(t *testing.T) {
 		mutex := NewMutex()
 		mutex.SemWait(targetResource, firstProcess)
 		mutex.SemSignal(targetResource, firstProcess)
-		success := mutex.SemSignal(targetResource, firstProcess)
+		_, success := mutex.SemSignal(targetResource, firstProcess)
 		if success {
 			t.Fatalf("Expected to fail to release the lock for resource %q to process %q\n", targetResource, firstProcess)
 		}
@@ -95,7 +96,7 @@ func TestMutex(t *testing.T) {
 		targetResource := "userInput"
 		mutex := NewMutex()
 		mutex.SemWait(targetResource, firstProcess)
-		success := mutex.SemSignal(targetResource, secondProcess)
+		_, success := mutex.SemSignal(targetResource, secondProcess)
 
 		if success {
 			t.Fatalf("Expected to fail to release the lock for resource %q to process %q\n", targetResource, secondProcess)
@@ -115,10 +116,134 @@ func TestMutex(t *testing.T) {
 			t.Fatalf("Failed to acquire the lock for resource %q to process %q\n", targetResource, secondProcess)
 		}
 
-		success = mutex.SemSignal(targetResource, secondProcess)
+		_, success = mutex.SemSignal(targetResource, secondProcess)
 		if !success {
 			t.Fatalf("Failed to release the lock for resource %q to process %d\n", targetResource, secondProcess)
 		}
 
 	})
+	t.Run("test release lock hands ownership to first blocked process", func(t *testing.T) {
+		mutex := NewMutex()
+		const firstProcess Process = 1
+		const secondProcess Process = 2
+		const thirdProcess Process = 3
+		targetResource := "userInput"
+		mutex.SemWait(targetResource, firstProcess)
+		mutex.SemWait(targetResource, secondProcess)
+		mutex.SemWait(targetResource, thirdProcess)
+
+		wokenProcess, success := mutex.SemSignal(targetResource, firstProcess)
+		if !success {
+			t.Fatalf("Failed to release the lock for resource %q to process %d\n", targetResource, firstProcess)
+		}
+		if wokenProcess != secondProcess {
+			t.Fatalf("Expected process %d, found %d\n", secondProcess, wokenProcess)
+		}
+		if owner, _ := mutex.Owner(targetResource); owner != secondProcess {
+			t.Fatalf("Expected process %d, found %d\n", secondProcess, owner)
+		}
+		expected := []Process{thirdProcess}
+		if waiters := mutex.Waiters(targetResource); !reflect.DeepEqual(expected, waiters) {
+			t.Fatalf("Expected waiters %v, found %v\n", expected, waiters)
+		}
+
+		wokenProcess, _ = mutex.SemSignal(targetResource, secondProcess)
+		if wokenProcess != thirdProcess {
+			t.Fatalf("Expected process %d, found %d\n", thirdProcess, wokenProcess)
+		}
+		wokenProcess, _ = mutex.SemSignal(targetResource, thirdProcess)
+		if wokenProcess != NoProcess {
+			t.Fatalf("Expected process %d, found %d\n", NoProcess, wokenProcess)
+		}
+		if _, isLocked := mutex.Owner(targetResource); isLocked {
+			t.Fatalf("Expected resource %q to be free\n", targetResource)
+		}
+	})
+
+	t.Run("test release all resources of a process", func(t *testing.T) {
+		mutex := NewMutex()
+		const firstProcess Process = 1
+		const secondProcess Process = 2
+		const thirdProcess Process = 3
+		mutex.SemWait("file", firstProcess)
+		mutex.SemWait("file", secondProcess)
+		mutex.SemWait("userInput", thirdProcess)
+		mutex.SemWait("userInput", firstProcess)
+
+		wokenProcesses := mutex.ReleaseAll(firstProcess)
+		expected := []Process{secondProcess}
+		if !reflect.DeepEqual(expected, wokenProcesses) {
+			t.Fatalf("Expected woken processes %v, found %v\n", expected, wokenProcesses)
+		}
+		if waiters := mutex.Waiters("userInput"); len(waiters) != 0 {
+			t.Fatalf("Expected no waiters, found %v\n", waiters)
+		}
+	})
+
+	t.Run("test release all wakes processes in resource order", func(t *testing.T) {
+		mutex := NewMutex()
+		const firstProcess Process = 1
+		names := []string{"printer", "file", "userInput", "disk", "buffer"}
+		for index, name := range names {
+			mutex.SemWait(name, firstProcess)
+			mutex.SemWait(name, Process(index+2))
+		}
+
+		// buffer, disk, file, printer and userInput sorted by name
+		expected := []Process{6, 5, 3, 2, 4}
+		if wokenProcesses := mutex.ReleaseAll(firstProcess); !reflect.DeepEqual(expected, wokenProcesses) {
+			t.Fatalf("Expected woken processes %v, found %v\n", expected, wokenProcesses)
+		}
+	})
+
+	t.Run("test declared semaphore allows multiple processes", func(t *testing.T) {
+		mutex := NewMutex()
+		const firstProcess Process = 1
+		const secondProcess Process = 2
+		const thirdProcess Process = 3
+		targetResource := "buffer"
+		if err := mutex.SemInit(targetResource, 2); err != nil {
+			t.Fatalf("Unexpected error %v\n", err)
+		}
+		if !mutex.SemWait(targetResource, firstProcess) || !mutex.SemWait(targetResource, secondProcess) {
+			t.Fatalf("Expected two processes to acquire resource %q\n", targetResource)
+		}
+		if mutex.SemWait(targetResource, thirdProcess) {
+			t.Fatalf("Expected process %d to be blocked\n", thirdProcess)
+		}
+
+		// any process can signal a counting semaphore
+		wokenProcess, success := mutex.SemSignal(targetResource, thirdProcess)
+		if !success || wokenProcess != thirdProcess {
+			t.Fatalf("Expected process %d to be woken, found %d\n", thirdProcess, wokenProcess)
+		}
+	})
+
+	t.Run("test invalid semaphore declarations", func(t *testing.T) {
+		mutex := NewMutex()
+		if err := mutex.SemInit("buffer", -1); err != ErrNegativeCount {
+			t.Fatalf("Expected %v, found %v\n", ErrNegativeCount, err)
+		}
+		mutex.SemWait("file", 1)
+		if err := mutex.SemInit("file", 1); err != ErrResourceExists {
+			t.Fatalf("Expected %v, found %v\n", ErrResourceExists, err)
+		}
+		mutex.SemInit("buffer", 1)
+		if err := mutex.SemInit("buffer", 2); err != ErrResourceExists {
+			t.Fatalf("Expected %v, found %v\n", ErrResourceExists, err)
+		}
+	})
+
+	t.Run("test redeclare semaphore with the same count", func(t *testing.T) {
+		mutex := NewMutex()
+		mutex.SemInit("buffer", 1)
+		mutex.SemWait("buffer", 1)
+		if err := mutex.SemInit("buffer", 1); err != nil {
+			t.Fatalf("Unexpected error %v\n", err)
+		}
+		// the semaphore keeps its state
+		if mutex.SemWait("buffer", 2) {
+			t.Fatalf("Expected process %d to be blocked\n", 2)
+		}
+	})
 }
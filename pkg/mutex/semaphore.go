@@ -0,0 +1,61 @@
+package mutex
+
+// Semaphore represents a counting semaphore with a queue of blocked processes.
+// A negative count is the number of processes waiting for the semaphore.
+type Semaphore struct {
+	count            int
+	initialCount     int
+	blockedProcesses []Process
+}
+
+// NewSemaphore creates a new Semaphore instance with the given initial count.
+func NewSemaphore(count int) *Semaphore {
+	return &Semaphore{count: count, initialCount: count, blockedProcesses: []Process{}}
+}
+
+// Wait decrements the count of the semaphore.
+// If the count becomes negative, the calling process is added to the list of blocked processes.
+// Returns true if the process may continue, false if it is blocked.
+func (s *Semaphore) Wait(process Process) bool {
+	s.count--
+	if s.count >= 0 {
+		return true
+	}
+	s.blockedProcesses = append(s.blockedProcesses, process)
+	return false
+}
+
+// Signal increments the count of the semaphore and wakes the first blocked process in FIFO order.
+// Returns the woken process, NoProcess if there are no blocked processes.
+func (s *Semaphore) Signal() Process {
+	s.count++
+	if len(s.blockedProcesses) == 0 {
+		return NoProcess
+	}
+	wokenProcess := s.blockedProcesses[0]
+	s.blockedProcesses = s.blockedProcesses[1:]
+	return wokenProcess
+}
+
+// Count returns the current count of the semaphore.
+func (s *Semaphore) Count() int {
+	return s.count
+}
+
+// Waiters returns the processes blocked on the semaphore in the order they will be woken.
+func (s *Semaphore) Waiters() []Process {
+	waiters := make([]Process, len(s.blockedProcesses))
+	copy(waiters, s.blockedProcesses)
+	return waiters
+}
+
+// remove removes the given process from the blocked processes and gives its unit back.
+func (s *Semaphore) remove(process Process) {
+	for idx, blockedProcess := range s.blockedProcesses {
+		if blockedProcess == process {
+			s.blockedProcesses = append(s.blockedProcesses[:idx:idx], s.blockedProcesses[idx+1:]...)
+			s.count++
+			return
+		}
+	}
+}
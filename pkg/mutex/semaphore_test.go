@@ -0,0 +1,55 @@
+package mutex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSemaphore(t *testing.T) {
+	t.Run("test processes continue while count is positive", func(t *testing.T) {
+		semaphore := NewSemaphore(2)
+		if !semaphore.Wait(1) || !semaphore.Wait(2) {
+			t.Fatalf("Expected both processes to continue\n")
+		}
+		if semaphore.Count() != 0 {
+			t.Fatalf("Expected count 0, found %d\n", semaphore.Count())
+		}
+	})
+
+	t.Run("test process blocks when count becomes negative", func(t *testing.T) {
+		semaphore := NewSemaphore(1)
+		semaphore.Wait(1)
+		if semaphore.Wait(2) {
+			t.Fatalf("Expected process %d to be blocked\n", 2)
+		}
+		if semaphore.Wait(3) {
+			t.Fatalf("Expected process %d to be blocked\n", 3)
+		}
+		if semaphore.Count() != -2 {
+			t.Fatalf("Expected count -2, found %d\n", semaphore.Count())
+		}
+		expected := []Process{2, 3}
+		if waiters := semaphore.Waiters(); !reflect.DeepEqual(expected, waiters) {
+			t.Fatalf("Expected waiters %v, found %v\n", expected, waiters)
+		}
+	})
+
+	t.Run("test signal wakes blocked processes in order", func(t *testing.T) {
+		semaphore := NewSemaphore(0)
+		semaphore.Wait(1)
+		semaphore.Wait(2)
+
+		if woken := semaphore.Signal(); woken != 1 {
+			t.Fatalf("Expected process %d, found %d\n", 1, woken)
+		}
+		if woken := semaphore.Signal(); woken != 2 {
+			t.Fatalf("Expected process %d, found %d\n", 2, woken)
+		}
+		if woken := semaphore.Signal(); woken != NoProcess {
+			t.Fatalf("Expected process %d, found %d\n", NoProcess, woken)
+		}
+		if semaphore.Count() != 1 {
+			t.Fatalf("Expected count 1, found %d\n", semaphore.Count())
+		}
+	})
+}
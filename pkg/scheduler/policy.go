@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"errors"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+// Policy decides which ready process gets the cpu and when the running process is preempted.
+type Policy interface {
+	// Select returns the index in the ready queue of the process that should run next.
+	Select(readyQueue []*memory.PCB) int
+	// TimeSlice returns the number of instructions the selected process may execute,
+	// or Unlimited to let it run until it blocks or terminates.
+	TimeSlice(process *memory.PCB, quantum int) int
+	// Preempt reports whether the running process must give up the cpu before its time slice expires.
+	Preempt(running *memory.PCB, readyQueue []*memory.PCB) bool
+	// Expire is called when the running process consumes its whole time slice.
+	Expire(process *memory.PCB)
+}
+
+// Unlimited is the time slice of a process that is never preempted by the clock.
+const Unlimited = -1
+
+const (
+	RoundRobinPolicy              = "rr"
+	FirstComeFirstServePolicy     = "fcfs"
+	ShortestJobFirstPolicy        = "sjf"
+	ShortestRemainingTimePolicy   = "srtf"
+	PriorityPolicy                = "priority"
+	MultilevelFeedbackQueuePolicy = "mlfq"
+)
+
+// DefaultFeedbackLevels is the number of queues used by the multilevel feedback queue policy.
+const DefaultFeedbackLevels = 3
+
+var ErrUnknownPolicy = errors.New("unknown scheduling policy.")
+
+// NewPolicy creates the scheduling policy with the given name.
+func NewPolicy(name string) (Policy, error) {
+	switch name {
+	case RoundRobinPolicy:
+		return &RoundRobin{}, nil
+	case FirstComeFirstServePolicy:
+		return &FirstComeFirstServe{}, nil
+	case ShortestJobFirstPolicy:
+		return &ShortestJobFirst{}, nil
+	case ShortestRemainingTimePolicy:
+		return &ShortestRemainingTime{}, nil
+	case PriorityPolicy:
+		return &Priority{}, nil
+	case MultilevelFeedbackQueuePolicy:
+		return NewMultilevelFeedbackQueue(DefaultFeedbackLevels), nil
+	}
+	return nil, ErrUnknownPolicy
+}
+
+// RoundRobin runs processes in arrival order, each one for a single quantum.
+type RoundRobin struct{}
+
+func (p *RoundRobin) Select(readyQueue []*memory.PCB) int {
+	return 0
+}
+
+func (p *RoundRobin) TimeSlice(process *memory.PCB, quantum int) int {
+	return quantum
+}
+
+func (p *RoundRobin) Preempt(running *memory.PCB, readyQueue []*memory.PCB) bool {
+	return false
+}
+
+func (p *RoundRobin) Expire(process *memory.PCB) {}
+
+// FirstComeFirstServe runs processes in arrival order until they block or terminate.
+type FirstComeFirstServe struct{}
+
+func (p *FirstComeFirstServe) Select(readyQueue []*memory.PCB) int {
+	return 0
+}
+
+func (p *FirstComeFirstServe) TimeSlice(process *memory.PCB, quantum int) int {
+	return Unlimited
+}
+
+func (p *FirstComeFirstServe) Preempt(running *memory.PCB, readyQueue []*memory.PCB) bool {
+	return false
+}
+
+func (p *FirstComeFirstServe) Expire(process *memory.PCB) {}
+
+// ShortestJobFirst runs the process with the smallest code size until it blocks or terminates.
+type ShortestJobFirst struct{}
+
+func (p *ShortestJobFirst) Select(readyQueue []*memory.PCB) int {
+	return minIndex(readyQueue, func(process *memory.PCB) int { return process.CodeSize })
+}
+
+func (p *ShortestJobFirst) TimeSlice(process *memory.PCB, quantum int) int {
+	return Unlimited
+}
+
+func (p *ShortestJobFirst) Preempt(running *memory.PCB, readyQueue []*memory.PCB) bool {
+	return false
+}
+
+func (p *ShortestJobFirst) Expire(process *memory.PCB) {}
+
+// ShortestRemainingTime runs the process with the fewest remaining instructions
+// and preempts it as soon as a shorter one becomes ready.
+type ShortestRemainingTime struct{}
+
+func (p *ShortestRemainingTime) Select(readyQueue []*memory.PCB) int {
+	return minIndex(readyQueue, (*memory.PCB).RemainingInstructions)
+}
+
+func (p *ShortestRemainingTime) TimeSlice(process *memory.PCB, quantum int) int {
+	return Unlimited
+}
+
+func (p *ShortestRemainingTime) Preempt(running *memory.PCB, readyQueue []*memory.PCB) bool {
+	return readyQueue[p.Select(readyQueue)].RemainingInstructions() < running.RemainingInstructions()
+}
+
+func (p *ShortestRemainingTime) Expire(process *memory.PCB) {}
+
+// Priority runs the process with the lowest priority value and preempts it
+// as soon as a process with a lower value becomes ready.
+type Priority struct{}
+
+func (p *Priority) Select(readyQueue []*memory.PCB) int {
+	return minIndex(readyQueue, func(process *memory.PCB) int { return process.Priority })
+}
+
+func (p *Priority) TimeSlice(process *memory.PCB, quantum int) int {
+	return Unlimited
+}
+
+func (p *Priority) Preempt(running *memory.PCB, readyQueue []*memory.PCB) bool {
+	return readyQueue[p.Select(readyQueue)].Priority < running.Priority
+}
+
+func (p *Priority) Expire(process *memory.PCB) {}
+
+// MultilevelFeedbackQueue runs processes from the highest level queue first.
+// The quantum doubles at every level and a process that consumes its whole slice is demoted.
+type MultilevelFeedbackQueue struct {
+	levels       int
+	processLevel map[int]int
+}
+
+// NewMultilevelFeedbackQueue creates a multilevel feedback queue policy with the given number of levels.
+func NewMultilevelFeedbackQueue(levels int) *MultilevelFeedbackQueue {
+	return &MultilevelFeedbackQueue{
+		levels:       levels,
+		processLevel: make(map[int]int),
+	}
+}
+
+func (p *MultilevelFeedbackQueue) Select(readyQueue []*memory.PCB) int {
+	return minIndex(readyQueue, p.level)
+}
+
+func (p *MultilevelFeedbackQueue) TimeSlice(process *memory.PCB, quantum int) int {
+	return quantum << p.level(process)
+}
+
+func (p *MultilevelFeedbackQueue) Preempt(running *memory.PCB, readyQueue []*memory.PCB) bool {
+	return p.level(readyQueue[p.Select(readyQueue)]) < p.level(running)
+}
+
+func (p *MultilevelFeedbackQueue) Expire(process *memory.PCB) {
+	if level := p.level(process); level < p.levels-1 {
+		p.processLevel[process.Id] = level + 1
+	}
+}
+
+func (p *MultilevelFeedbackQueue) level(process *memory.PCB) int {
+	return p.processLevel[process.Id]
+}
+
+// minIndex returns the index of the first process with the smallest key.
+func minIndex(readyQueue []*memory.PCB, key func(process *memory.PCB) int) int {
+	selected := 0
+	for idx, process := range readyQueue {
+		if key(process) < key(readyQueue[selected]) {
+			selected = idx
+		}
+	}
+	return selected
+}
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/KhaledHegazy222/os-simulator/pkg/memory"
+)
+
+func newReadyProcess(id int, codeSize int) *memory.PCB {
+	return &memory.PCB{
+		Id:       id,
+		State:    memory.Ready,
+		Start:    1,
+		PC:       1 + memory.PCBSize,
+		CodeSize: codeSize,
+	}
+}
+
+// runSchedule adds the given processes and returns the ids of the processes selected for the given number of ticks.
+// Every selected process executes one instruction per tick.
+func runSchedule(t *testing.T, policyName string, quantum int, ticks int, processes ...*memory.PCB) []int {
+	t.Helper()
+	s := NewScheduler()
+	policy, err := NewPolicy(policyName)
+	if err != nil {
+		t.Fatalf("expected nil, found %v", err)
+	}
+	s.SetPolicy(policy)
+	s.SetQuantum(quantum)
+	for _, process := range processes {
+		s.AddToReadyQueue(process)
+	}
+
+	ids := make([]int, 0, ticks)
+	for i := 0; i < ticks; i++ {
+		process, err := s.GetNextReadyProcess()
+		if err != nil {
+			t.Fatalf("expected nil, found %v", err)
+		}
+		ids = append(ids, process.Id)
+		process.PC++
+		if process.RemainingInstructions() == 0 {
+			s.TerminateProcess(process.Id)
+		}
+	}
+	return ids
+}
+
+func assertSchedule(t *testing.T, expected []int, found []int) {
+	t.Helper()
+	if len(expected) != len(found) {
+		t.Fatalf("expected %v, found %v", expected, found)
+	}
+	for i := range expected {
+		if expected[i] != found[i] {
+			t.Fatalf("expected %v, found %v", expected, found)
+		}
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	for _, name := range []string{RoundRobinPolicy, FirstComeFirstServePolicy, ShortestJobFirstPolicy,
+		ShortestRemainingTimePolicy, PriorityPolicy, MultilevelFeedbackQueuePolicy} {
+		if _, err := NewPolicy(name); err != nil {
+			t.Errorf("%v: expected nil, found %v", name, err)
+		}
+	}
+	if _, err := NewPolicy("lottery"); err != ErrUnknownPolicy {
+		t.Errorf("expected %v, found %v", ErrUnknownPolicy, err)
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	found := runSchedule(t, RoundRobinPolicy, 2, 7, newReadyProcess(1, 3), newReadyProcess(2, 4))
+	assertSchedule(t, []int{1, 1, 2, 2, 1, 2, 2}, found)
+}
+
+func TestFirstComeFirstServe(t *testing.T) {
+	found := runSchedule(t, FirstComeFirstServePolicy, 1, 5, newReadyProcess(1, 3), newReadyProcess(2, 2))
+	assertSchedule(t, []int{1, 1, 1, 2, 2}, found)
+}
+
+func TestShortestJobFirst(t *testing.T) {
+	found := runSchedule(t, ShortestJobFirstPolicy, 1, 6, newReadyProcess(1, 4), newReadyProcess(2, 2))
+	assertSchedule(t, []int{2, 2, 1, 1, 1, 1}, found)
+}
+
+func TestShortestRemainingTime(t *testing.T) {
+	s := NewScheduler()
+	s.SetPolicy(&ShortestRemainingTime{})
+	long := newReadyProcess(1, 5)
+	short := newReadyProcess(2, 2)
+	s.AddToReadyQueue(long)
+
+	if process, _ := s.GetNextReadyProcess(); process != long {
+		t.Fatalf("expected process %v, found %v", long.Id, process.Id)
+	}
+	long.PC++
+
+	// a shorter process arrives and preempts the running one
+	s.AddToReadyQueue(short)
+	if process, _ := s.GetNextReadyProcess(); process != short {
+		t.Fatalf("expected process %v, found %v", short.Id, process.Id)
+	}
+}
+
+func TestPriority(t *testing.T) {
+	low := newReadyProcess(1, 3)
+	low.Priority = 5
+	high := newReadyProcess(2, 2)
+	high.Priority = 1
+
+	found := runSchedule(t, PriorityPolicy, 1, 5, low, high)
+	assertSchedule(t, []int{2, 2, 1, 1, 1}, found)
+}
+
+func TestMultilevelFeedbackQueue(t *testing.T) {
+	t.Run("demote process that consumes its whole slice", func(t *testing.T) {
+		policy := NewMultilevelFeedbackQueue(3)
+		process := newReadyProcess(1, 10)
+
+		if slice := policy.TimeSlice(process, 2); slice != 2 {
+			t.Errorf("expected 2, found %v", slice)
+		}
+		policy.Expire(process)
+		if slice := policy.TimeSlice(process, 2); slice != 4 {
+			t.Errorf("expected 4, found %v", slice)
+		}
+		policy.Expire(process)
+		policy.Expire(process)
+		if slice := policy.TimeSlice(process, 2); slice != 8 {
+			t.Errorf("expected 8, found %v", slice)
+		}
+	})
+
+	t.Run("schedule higher levels first", func(t *testing.T) {
+		found := runSchedule(t, MultilevelFeedbackQueuePolicy, 1, 8, newReadyProcess(1, 4), newReadyProcess(2, 4))
+		assertSchedule(t, []int{1, 2, 1, 1, 2, 2, 1, 2}, found)
+	})
+}
@@ -7,9 +7,11 @@ import (
 )
 
 type Scheduler struct {
-	readyQueue           queue
-	blockedQueue         queue
-	readyProcessIterator int
+	readyQueue   queue
+	blockedQueue queue
+	running      *memory.PCB
+	quantum      int
+	policy       Policy
 }
 
 var (
@@ -17,19 +19,40 @@ var (
 	ErrProcessNotBlocked = errors.New("process state is not blocked.")
 	ErrNoReadyProcesses  = errors.New("no processes in the ready queue.")
 	ErrProcessNotFound   = errors.New("process is not found in the queue.")
+	ErrInvalidQuantum    = errors.New("quantum must be at least one instruction.")
 )
 
+// DefaultQuantum is the number of instructions a process executes before preemption.
+const DefaultQuantum = 1
+
 // NewScheduler factory function that creates new scheduler
 func NewScheduler() *Scheduler {
 	readyQueue := make([]*memory.PCB, 0)
 	blockedQueue := make([]*memory.PCB, 0)
 	return &Scheduler{
-		readyQueue:           readyQueue,
-		blockedQueue:         blockedQueue,
-		readyProcessIterator: 0,
+		readyQueue:   readyQueue,
+		blockedQueue: blockedQueue,
+		running:      nil,
+		quantum:      DefaultQuantum,
+		policy:       &RoundRobin{},
 	}
 }
 
+// SetPolicy sets the policy that decides which ready process runs next.
+func (s *Scheduler) SetPolicy(policy Policy) {
+	s.policy = policy
+	s.running = nil
+}
+
+// SetQuantum sets the number of instructions each process executes per time slice.
+func (s *Scheduler) SetQuantum(quantum int) error {
+	if quantum < 1 {
+		return ErrInvalidQuantum
+	}
+	s.quantum = quantum
+	return nil
+}
+
 // AddToReadyQueue adds the given pcb to the ready queue.
 func (s *Scheduler) AddToReadyQueue(process *memory.PCB) error {
 	if process.State != memory.Ready {
@@ -40,14 +63,31 @@ func (s *Scheduler) AddToReadyQueue(process *memory.PCB) error {
 }
 
 // GetNextReadyProcess gets next ready process from the ready queue.
+// The running process is returned until its time slice expires or the policy preempts it,
+// then it moves to the back of the ready queue and the policy selects the next one.
 func (s *Scheduler) GetNextReadyProcess() (*memory.PCB, error) {
 	if len(s.readyQueue) == 0 {
 		return &memory.PCB{}, ErrNoReadyProcesses
 	}
 
-	readyProcess := s.readyQueue[s.readyProcessIterator]
-	s.incrementIterator()
-	return readyProcess, nil
+	if s.running != nil {
+		expired := s.running.RemainingQuantum == 0
+		if expired {
+			s.policy.Expire(s.running)
+		}
+		if expired || s.policy.Preempt(s.running, s.readyQueue) {
+			s.preempt()
+		}
+	}
+
+	if s.running == nil {
+		s.running = s.readyQueue[s.policy.Select(s.readyQueue)]
+		s.running.RemainingQuantum = s.policy.TimeSlice(s.running, s.quantum)
+	}
+	if s.running.RemainingQuantum > 0 {
+		s.running.RemainingQuantum--
+	}
+	return s.running, nil
 }
 
 // BlockProcess move pcb with given pid from ready queue to block queue.
@@ -55,7 +95,7 @@ func (s *Scheduler) BlockProcess(pid int) error {
 	for idx, process := range s.readyQueue {
 		if process.Id == pid {
 			pcb := s.removeFromReadyQueue(idx)
-			s.normalizeIterator(idx)
+			s.stopIfRunning(pcb)
 			pcb.State = memory.Blocked
 			s.addToBlockedQueue(pcb)
 			return nil
@@ -77,22 +117,40 @@ func (s *Scheduler) UnBlockProcess(pid int) error {
 	return ErrProcessNotFound
 }
 
-// TerminateProcess remove process with given pid from the ready queue.
+// TerminateProcess remove process with given pid from the ready queue or the blocked queue.
 func (s *Scheduler) TerminateProcess(pid int) error {
 	for idx, process := range s.readyQueue {
 		if process.Id == pid {
-			s.removeFromReadyQueue(idx)
-			s.normalizeIterator(idx)
+			pcb := s.removeFromReadyQueue(idx)
+			s.stopIfRunning(pcb)
+			return nil
+		}
+	}
+	for idx, process := range s.blockedQueue {
+		if process.Id == pid {
+			s.removeFromBlockedQueue(idx)
 			return nil
 		}
 	}
 	return ErrProcessNotFound
 }
 
-func (s *Scheduler) incrementIterator() {
-	s.readyProcessIterator++
-	if s.readyProcessIterator == len(s.readyQueue) {
-		s.readyProcessIterator = 0
+// preempt moves the running process to the back of the ready queue.
+func (s *Scheduler) preempt() {
+	for idx, process := range s.readyQueue {
+		if process == s.running {
+			s.readyQueue.append(s.removeFromReadyQueue(idx))
+			break
+		}
+	}
+	s.running.RemainingQuantum = 0
+	s.running = nil
+}
+
+func (s *Scheduler) stopIfRunning(process *memory.PCB) {
+	process.RemainingQuantum = 0
+	if process == s.running {
+		s.running = nil
 	}
 }
 
@@ -111,12 +169,3 @@ func (s *Scheduler) removeFromBlockedQueue(index int) *memory.PCB {
 func (s *Scheduler) removeFromReadyQueue(index int) *memory.PCB {
 	return s.readyQueue.delete(index)
 }
-
-func (s *Scheduler) normalizeIterator(deletedIndex int){
-	if s.readyProcessIterator > deletedIndex {
-		s.readyProcessIterator--
-	}
-	if s.readyProcessIterator == deletedIndex && deletedIndex==len(s.readyQueue)-1{
-		s.readyProcessIterator=0
-	}
-}
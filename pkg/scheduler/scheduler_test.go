@@ -240,7 +240,23 @@ func TestTerminateProcess(t *testing.T) {
 	})
 }
 
-func TestIncrementIterator(t *testing.T) {
+func TestTerminateBlockedProcess(t *testing.T) {
+	s := NewScheduler()
+	blockedProcess := &memory.PCB{
+		Id:    1,
+		State: memory.Blocked,
+	}
+	s.addToBlockedQueue(blockedProcess)
+
+	if err := s.TerminateProcess(blockedProcess.Id); err != nil {
+		t.Errorf("expected nil, found %v", err)
+	}
+	if len(s.blockedQueue) != 0 {
+		t.Errorf("expected 0, found %v", len(s.blockedQueue))
+	}
+}
+
+func TestRoundRobinRotation(t *testing.T) {
 	s := NewScheduler()
 
 	firstReadyProcess := &memory.PCB{
@@ -375,95 +391,76 @@ func TestRemoveFromReadyQueue(t *testing.T) {
 
 }
 
-func TestNormalizeIterator(t *testing.T) {
+func TestSetQuantum(t *testing.T) {
+	s := NewScheduler()
 
-	t.Run("iterator is less than deleted process index", func(t *testing.T) {
+	if err := s.SetQuantum(0); err != ErrInvalidQuantum {
+		t.Errorf("expected %v, found %v", ErrInvalidQuantum, err)
+	}
+	if err := s.SetQuantum(3); err != nil {
+		t.Errorf("expected nil, found %v", err)
+	}
+	if s.quantum != 3 {
+		t.Errorf("expected 3, found %v", s.quantum)
+	}
+}
+
+func TestQuantum(t *testing.T) {
+	t.Run("process keeps the cpu until its quantum is consumed", func(t *testing.T) {
 		s := NewScheduler()
+		s.SetQuantum(2)
 
-		readyProcess := &memory.PCB{
+		firstReadyProcess := &memory.PCB{
 			Id:    1,
 			State: memory.Ready,
 		}
-
-		// add four ready processes to the ready queue
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-
-		// iterator is zero and index deleted is two, so should not change
-		s.readyProcessIterator = 0
-		s.normalizeIterator(2)
-
-		if s.readyProcessIterator != 0 {
-			t.Errorf("expected 0, found %v", s.readyProcessIterator)
-		}
-	})
-
-	t.Run("iterator is equal to index,not last index", func(t *testing.T) {
-		s := NewScheduler()
-
-		readyProcess := &memory.PCB{
-			Id:    1,
+		secondReadyProcess := &memory.PCB{
+			Id:    2,
 			State: memory.Ready,
 		}
+		s.AddToReadyQueue(firstReadyProcess)
+		s.AddToReadyQueue(secondReadyProcess)
 
-		// add four ready processes to the ready queue
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-
-		// iterator is one and index is one and it's not last index, so should not change
-		s.readyProcessIterator = 1
-		s.normalizeIterator(1)
-		if s.readyProcessIterator != 1 {
-			t.Errorf("expected 1, found %v", s.readyProcessIterator)
+		expected := []*memory.PCB{firstReadyProcess, firstReadyProcess, secondReadyProcess, secondReadyProcess, firstReadyProcess}
+		for i, expectedProcess := range expected {
+			process, err := s.GetNextReadyProcess()
+			if err != nil {
+				t.Fatalf("expected nil, found %v", err)
+			}
+			if process != expectedProcess {
+				t.Errorf("step %v: expected process %v, found %v", i, expectedProcess.Id, process.Id)
+			}
+		}
+		if firstReadyProcess.RemainingQuantum != 1 {
+			t.Errorf("expected 1, found %v", firstReadyProcess.RemainingQuantum)
 		}
 	})
 
-	t.Run("iterator is equal to index, last index", func(t *testing.T) {
+	t.Run("terminate last process in the middle of its quantum", func(t *testing.T) {
 		s := NewScheduler()
+		s.SetQuantum(2)
 
-		readyProcess := &memory.PCB{
+		firstReadyProcess := &memory.PCB{
 			Id:    1,
 			State: memory.Ready,
 		}
-
-		// add four ready processes to the ready queue
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-
-		// iterator is three and index is three, so should become 0
-		s.readyProcessIterator = 3
-		s.normalizeIterator(3)
-		if s.readyProcessIterator != 0 {
-			t.Errorf("expected 0, found %v", s.readyProcessIterator)
-		}
-	})
-
-	t.Run("iterator is greater than deleted process index", func(t *testing.T) {
-		s := NewScheduler()
-
-		readyProcess := &memory.PCB{
-			Id:    1,
+		secondReadyProcess := &memory.PCB{
+			Id:    2,
 			State: memory.Ready,
 		}
+		s.AddToReadyQueue(firstReadyProcess)
+		s.AddToReadyQueue(secondReadyProcess)
 
-		// add four ready processes to the ready queue
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
-		s.AddToReadyQueue(readyProcess)
+		s.GetNextReadyProcess()
+		s.GetNextReadyProcess()
+		s.GetNextReadyProcess()
+		s.TerminateProcess(secondReadyProcess.Id)
 
-		// iterator is three and index is one, so should subtract one from the iterator
-		s.readyProcessIterator = 3
-		s.normalizeIterator(1)
-		if s.readyProcessIterator != 2 {
-			t.Errorf("expected 2, found %v", s.readyProcessIterator)
+		if process, _ := s.GetNextReadyProcess(); process != firstReadyProcess {
+			t.Errorf("expected process %v, found %v", firstReadyProcess.Id, process.Id)
+		}
+		if secondReadyProcess.RemainingQuantum != 0 {
+			t.Errorf("expected 0, found %v", secondReadyProcess.RemainingQuantum)
 		}
-
 	})
 }
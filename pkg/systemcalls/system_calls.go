@@ -1,16 +1,51 @@
 package systemcalls
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 	"strings"
+	"sync"
 )
 
-type OS struct{}
+type OS struct {
+	reader *bufio.Reader
+	writer io.Writer
+}
+
+var (
+	// bufferedReaders holds the buffered reader of every underlying reader, so the oses reading from
+	// the same reader don't take the buffered input of each other
+	bufferedReaders      = map[io.Reader]*bufio.Reader{}
+	bufferedReadersMutex sync.Mutex
+)
+
+// bufferedReader returns the buffered reader shared by every os reading from the given reader
+func bufferedReader(reader io.Reader) *bufio.Reader {
+	if buffered, isBuffered := reader.(*bufio.Reader); isBuffered {
+		return buffered
+	}
+	if reader == nil || !reflect.TypeOf(reader).Comparable() {
+		return bufio.NewReader(reader)
+	}
+	bufferedReadersMutex.Lock()
+	defer bufferedReadersMutex.Unlock()
+	if _, isPresent := bufferedReaders[reader]; !isPresent {
+		bufferedReaders[reader] = bufio.NewReader(reader)
+	}
+	return bufferedReaders[reader]
+}
 
-// NewOS creates new object of os struct.
+// NewOS creates new object of os struct that reads from the standard input and prints to the standard output.
 func NewOS() *OS {
-	return &OS{}
+	return NewOSWithIO(os.Stdin, os.Stdout)
+}
+
+// NewOSWithIO creates new object of os struct that reads input from the given reader and prints to the given writer.
+func NewOSWithIO(reader io.Reader, writer io.Writer) *OS {
+	return &OS{reader: bufferedReader(reader), writer: writer}
 }
 
 // ReadFile read file from disk given its path.
@@ -35,18 +70,24 @@ func (o *OS) DeleteFile(path string) error {
 	return os.Remove(path)
 }
 
-// PrintToStdOut print given data to the screen
+// PrintToStdOut print given data to the output of the os
 func (o *OS) PrintToStdOut(data string) {
-	fmt.Println(data)
+	fmt.Fprintln(o.writer, data)
 }
 
 // GetInput takes input from the user
 func (o *OS) GetInput() string {
-	var input string
-	fmt.Scanln(&input)
+	input, _ := o.ReadInput()
 	return input
 }
 
+// ReadInput reads the next word separated by spaces or new lines from the input of the os
+func (o *OS) ReadInput() (string, error) {
+	var input string
+	_, err := fmt.Fscan(o.reader, &input)
+	return input, err
+}
+
 // ReadFromMemory read specific location of memory
 func (o *OS) ReadFromMemory() {
 }
@@ -1,7 +1,10 @@
 package systemcalls
 
 import (
+	"bytes"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -77,3 +80,47 @@ func TestWriteToFile(t *testing.T) {
 		}
 	})
 }
+
+func TestRedirectedIO(t *testing.T) {
+	t.Run("print to given writer", func(t *testing.T) {
+		var output bytes.Buffer
+		os := NewOSWithIO(strings.NewReader(""), &output)
+
+		os.PrintToStdOut("first line")
+		os.PrintToStdOut("second line")
+
+		expected := "first line\nsecond line\n"
+		if output.String() != expected {
+			t.Errorf("expected %q, found %q", expected, output.String())
+		}
+	})
+
+	t.Run("get input from given reader", func(t *testing.T) {
+		os := NewOSWithIO(strings.NewReader("first\nsecond\n"), &bytes.Buffer{})
+
+		if found := os.GetInput(); found != "first" {
+			t.Errorf("expected first, found %v", found)
+		}
+		if found := os.GetInput(); found != "second" {
+			t.Errorf("expected second, found %v", found)
+		}
+	})
+
+	t.Run("share the buffered input of a reader", func(t *testing.T) {
+		reader := strings.NewReader("first second\nthird\n")
+		firstOS := NewOSWithIO(reader, &bytes.Buffer{})
+		secondOS := NewOSWithIO(reader, &bytes.Buffer{})
+
+		for _, expected := range []string{"first", "second"} {
+			if found, err := firstOS.ReadInput(); err != nil || found != expected {
+				t.Errorf("expected %v, found %v and %v", expected, found, err)
+			}
+		}
+		if found, err := secondOS.ReadInput(); err != nil || found != "third" {
+			t.Errorf("expected third, found %v and %v", found, err)
+		}
+		if _, err := secondOS.ReadInput(); err != io.EOF {
+			t.Errorf("expected %v, found %v", io.EOF, err)
+		}
+	})
+}